@@ -13,8 +13,15 @@ func TestSubnetList(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Unable to create a subnet : %v", err)
 	}
-	allPages, err := subnets.List(client, subnets.ListOpts{})
-	tools.PrintResource(t, allPages)
+	allPages, err := subnets.List(client, subnets.ListOpts{}).AllPages()
+	if err != nil {
+		t.Fatalf("Unable to list subnets: %v", err)
+	}
+	allSubnets, err := subnets.ExtractSubnets(allPages)
+	if err != nil {
+		t.Fatalf("Unable to extract subnets: %v", err)
+	}
+	tools.PrintResource(t, allSubnets)
 
 }
 
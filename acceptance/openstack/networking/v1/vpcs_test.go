@@ -15,10 +15,14 @@ func TestVpcList(t *testing.T) {
 	}
 
 	listOpts := vpcs.ListOpts{}
-	allVpcs, err := vpcs.List(client, listOpts)
+	allPages, err := vpcs.List(client, listOpts).AllPages()
 	if err != nil {
 		t.Fatalf("Unable to list vpcs: %v", err)
 	}
+	allVpcs, err := vpcs.ExtractVpcs(allPages)
+	if err != nil {
+		t.Fatalf("Unable to extract vpcs: %v", err)
+	}
 	for _, vpc := range allVpcs {
 		tools.PrintResource(t, vpc)
 	}
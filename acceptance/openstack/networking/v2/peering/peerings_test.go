@@ -15,10 +15,14 @@ func TestPeeringList(t *testing.T) {
 	}
 
 	listOpts := peerings.ListOpts{}
-	peering, err := peerings.List(client, listOpts)
+	allPages, err := peerings.List(client, listOpts).AllPages()
 	if err != nil {
 		t.Fatalf("Unable to list peerings: %v", err)
 	}
+	peering, err := peerings.ExtractPeerings(allPages)
+	if err != nil {
+		t.Fatalf("Unable to extract peerings: %v", err)
+	}
 	for _, peering := range peering {
 		tools.PrintResource(t, peering)
 	}
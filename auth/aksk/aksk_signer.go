@@ -0,0 +1,235 @@
+package aksk
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// SignAlgorithm is the name of the signature algorithm.
+	SignAlgorithm = "SDK-HMAC-SHA256"
+
+	// HeaderXDate is the header carrying the signing time.
+	HeaderXDate = "X-Sdk-Date"
+
+	// HeaderAuthorization is the header carrying the signature.
+	HeaderAuthorization = "Authorization"
+
+	// HeaderHost is the host header, which is always signed.
+	HeaderHost = "Host"
+
+	// HeaderContentSha256 may be set by the caller to skip hashing the body,
+	// for example "UNSIGNED-PAYLOAD" for streamed uploads.
+	HeaderContentSha256 = "X-Sdk-Content-Sha256"
+
+	// BasicDateFormat is the layout of the X-Sdk-Date header.
+	BasicDateFormat = "20060102T150405Z"
+)
+
+// SignOptions contains the credentials used to sign a request.
+type SignOptions struct {
+	// AccessKey is the access key ID (AK).
+	AccessKey string
+
+	// SecretKey is the secret access key (SK).
+	SecretKey string
+
+	// Time is the signing time. The current time is used if it is zero.
+	Time time.Time
+}
+
+// Sign computes the SDK-HMAC-SHA256 signature of req and sets the X-Sdk-Date
+// and Authorization headers. The request body, if any, is read to compute its
+// hash and replaced with an equivalent in-memory reader.
+func Sign(req *http.Request, opts SignOptions) error {
+	if opts.AccessKey == "" || opts.SecretKey == "" {
+		return fmt.Errorf("AccessKey and SecretKey are required to sign a request")
+	}
+
+	t := opts.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	if v := req.Header.Get(HeaderXDate); v != "" {
+		parsed, err := time.Parse(BasicDateFormat, v)
+		if err != nil {
+			return err
+		}
+		t = parsed
+	} else {
+		req.Header.Set(HeaderXDate, t.UTC().Format(BasicDateFormat))
+	}
+
+	if req.Header.Get(HeaderHost) == "" {
+		host := req.Host
+		if host == "" {
+			host = req.URL.Host
+		}
+		req.Header.Set(HeaderHost, host)
+	}
+
+	signedHeaders := SignedHeaders(req)
+	canonical, err := CanonicalRequest(req, signedHeaders)
+	if err != nil {
+		return err
+	}
+
+	stringToSign := StringToSign(canonical, t)
+	signature := signStringToSign(stringToSign, []byte(opts.SecretKey))
+	req.Header.Set(HeaderAuthorization, authHeaderValue(signature, opts.AccessKey, signedHeaders))
+
+	return nil
+}
+
+// CanonicalRequest builds the canonical form of req over the given signed
+// headers:
+//
+//	Method
+//	CanonicalURI
+//	CanonicalQueryString
+//	CanonicalHeaders
+//	SignedHeaders
+//	HexEncode(Hash(RequestPayload))
+func CanonicalRequest(req *http.Request, signedHeaders []string) (string, error) {
+	hexencode := req.Header.Get(HeaderContentSha256)
+	if hexencode == "" {
+		data, err := requestPayload(req)
+		if err != nil {
+			return "", err
+		}
+		hexencode = hashHex(data)
+	}
+
+	return fmt.Sprintf("%s\n%s\n%s\n%s\n%s\n%s",
+		req.Method,
+		canonicalURI(req),
+		canonicalQueryString(req),
+		canonicalHeaders(req, signedHeaders),
+		strings.Join(signedHeaders, ";"),
+		hexencode), nil
+}
+
+// SignedHeaders returns the sorted, lower-cased names of the headers of req.
+func SignedHeaders(req *http.Request) []string {
+	var signed []string
+	for key := range req.Header {
+		signed = append(signed, strings.ToLower(key))
+	}
+	sort.Strings(signed)
+	return signed
+}
+
+// StringToSign builds the string signed with the secret key from a canonical
+// request and the signing time.
+func StringToSign(canonicalRequest string, t time.Time) string {
+	return fmt.Sprintf("%s\n%s\n%s", SignAlgorithm, t.UTC().Format(BasicDateFormat), hashHex([]byte(canonicalRequest)))
+}
+
+func requestPayload(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return []byte{}, nil
+	}
+	data, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body.Close()
+	req.Body = ioutil.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+func canonicalURI(req *http.Request) string {
+	pattens := strings.Split(req.URL.Path, "/")
+	var uri []string
+	for _, v := range pattens {
+		uri = append(uri, escape(v))
+	}
+	urlpath := strings.Join(uri, "/")
+	if len(urlpath) == 0 || urlpath[len(urlpath)-1] != '/' {
+		urlpath = urlpath + "/"
+	}
+	return urlpath
+}
+
+func canonicalQueryString(req *http.Request) string {
+	query := req.URL.Query()
+	var keys []string
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var a []string
+	for _, key := range keys {
+		k := escape(key)
+		values := query[key]
+		sort.Strings(values)
+		for _, v := range values {
+			a = append(a, k+"="+escape(v))
+		}
+	}
+	return strings.Join(a, "&")
+}
+
+func canonicalHeaders(req *http.Request, signedHeaders []string) string {
+	var a []string
+	header := make(map[string][]string)
+	for k, v := range req.Header {
+		header[strings.ToLower(k)] = v
+	}
+	for _, key := range signedHeaders {
+		value := header[key]
+		if key == "host" && len(value) == 0 {
+			value = []string{req.Host}
+		}
+		sort.Strings(value)
+		for _, v := range value {
+			a = append(a, key+":"+strings.TrimSpace(v))
+		}
+	}
+	return fmt.Sprintf("%s\n", strings.Join(a, "\n"))
+}
+
+func signStringToSign(stringToSign string, signingKey []byte) string {
+	h := hmac.New(sha256.New, signingKey)
+	h.Write([]byte(stringToSign))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func authHeaderValue(signature, accessKey string, signedHeaders []string) string {
+	return fmt.Sprintf("%s Access=%s, SignedHeaders=%s, Signature=%s",
+		SignAlgorithm, accessKey, strings.Join(signedHeaders, ";"), signature)
+}
+
+func hashHex(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+// escape percent-encodes s as required by the canonical request: every byte
+// except unreserved characters (A-Z, a-z, 0-9, '-', '_', '.', '~') is encoded.
+func escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if shouldNotEscape(c) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func shouldNotEscape(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+		c == '_' || c == '-' || c == '~' || c == '.'
+}
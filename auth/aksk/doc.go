@@ -0,0 +1,25 @@
+/*
+Package aksk implements the SDK-HMAC-SHA256 request signature used by the
+cloud's REST APIs to authenticate callers with an access key (AK) and a secret
+key (SK) instead of a Keystone token.
+
+Most users never call this package directly: setting AKSKAuthOptions on a
+golangsdk.ProviderClient, or authenticating with openstack.AuthenticatedClient
+and a golangsdk.AKSKAuthOptions value, signs every request automatically.
+
+Example to Sign a Request
+
+	req, err := http.NewRequest("GET", "https://vpc.example.com/v1/{project_id}/vpcs", nil)
+	if err != nil {
+		panic(err)
+	}
+
+	err = aksk.Sign(req, aksk.SignOptions{
+		AccessKey: "{access_key}",
+		SecretKey: "{secret_key}",
+	})
+	if err != nil {
+		panic(err)
+	}
+*/
+package aksk
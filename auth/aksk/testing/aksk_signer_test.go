@@ -0,0 +1,45 @@
+package testing
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/huaweicloud/golangsdk/auth/aksk"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"vpc":{"name":"test"}}`)
+	req, err := http.NewRequest("POST", "https://vpc.example.com/v1/123/vpcs?marker=a%20b&limit=10", bytes.NewReader(body))
+	th.AssertNoErr(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(aksk.HeaderXDate, "20191115T033655Z")
+
+	err = aksk.Sign(req, aksk.SignOptions{
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+	th.AssertNoErr(t, err)
+
+	expected := "SDK-HMAC-SHA256 Access=access, SignedHeaders=content-type;host;x-sdk-date, " +
+		"Signature=0787b5b17c904770e91fdadeadd2614dd5f57223d2df3890f981776734d33d83"
+	th.CheckEquals(t, expected, req.Header.Get(aksk.HeaderAuthorization))
+	th.CheckEquals(t, "vpc.example.com", req.Header.Get(aksk.HeaderHost))
+
+	// The body must still be readable after signing.
+	actual, err := ioutil.ReadAll(req.Body)
+	th.AssertNoErr(t, err)
+	th.CheckByteArrayEquals(t, body, actual)
+}
+
+func TestSignMissingKeys(t *testing.T) {
+	req, err := http.NewRequest("GET", "https://vpc.example.com/v1/123/vpcs", nil)
+	th.AssertNoErr(t, err)
+
+	err = aksk.Sign(req, aksk.SignOptions{AccessKey: "access"})
+	if err == nil {
+		t.Fatalf("expected an error when the secret key is missing")
+	}
+}
@@ -0,0 +1,2 @@
+// aksk unit tests
+package testing
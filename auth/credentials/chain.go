@@ -0,0 +1,58 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ErrNoValidProvider is returned by ChainProvider when none of its providers
+// has credentials. Errors holds the error of each provider, in order.
+type ErrNoValidProvider struct {
+	Errors []error
+}
+
+func (e ErrNoValidProvider) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("No valid credentials provider in chain: %s", strings.Join(msgs, "; "))
+}
+
+// ChainProvider retrieves credentials from the first of its providers that
+// has some.
+type ChainProvider struct {
+	Providers []Provider
+}
+
+// NewChainProvider returns a ChainProvider trying providers in order.
+func NewChainProvider(providers ...Provider) *ChainProvider {
+	return &ChainProvider{Providers: providers}
+}
+
+// NewDefaultChain returns Credentials retrieved from the environment, then
+// the shared credentials file, then the instance metadata service.
+func NewDefaultChain() *Credentials {
+	return NewCredentials(NewChainProvider(
+		&EnvProvider{},
+		&FileProvider{},
+		&MetadataProvider{},
+	))
+}
+
+// Retrieve implements Provider.
+func (p *ChainProvider) Retrieve(ctx context.Context) (Value, error) {
+	var errs []error
+	for _, provider := range p.Providers {
+		v, err := provider.Retrieve(ctx)
+		if err == nil {
+			return v, nil
+		}
+		if ctx.Err() != nil {
+			return Value{}, ctx.Err()
+		}
+		errs = append(errs, err)
+	}
+	return Value{}, ErrNoValidProvider{Errors: errs}
+}
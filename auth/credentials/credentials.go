@@ -0,0 +1,89 @@
+package credentials
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultExpiryWindow is how long before their expiry credentials are
+// retrieved again when Credentials.ExpiryWindow is zero.
+const DefaultExpiryWindow = 5 * time.Minute
+
+// Value holds an access key (AK), a secret key (SK) and, for temporary
+// credentials, the security token and expiry that come with them.
+type Value struct {
+	AccessKey     string
+	SecretKey     string
+	SecurityToken string
+
+	// ExpiresAt is the zero time for permanent credentials.
+	ExpiresAt time.Time
+
+	// Source is the name of the provider the credentials came from.
+	Source string
+}
+
+// Provider is a source of credentials, such as the environment, a file or
+// the instance metadata service.
+type Provider interface {
+	// Retrieve returns the credentials of the source, or an error if it has
+	// none.
+	Retrieve(ctx context.Context) (Value, error)
+}
+
+// Credentials caches the credentials of a Provider and retrieves them again
+// shortly before they expire. It is safe for concurrent use, and concurrent
+// callers share a single retrieval.
+type Credentials struct {
+	// ExpiryWindow is how long before their expiry the credentials are
+	// retrieved again. Zero means DefaultExpiryWindow.
+	ExpiryWindow time.Duration
+
+	provider Provider
+
+	mut   sync.Mutex
+	value *Value
+}
+
+// NewCredentials returns Credentials retrieved from provider.
+func NewCredentials(provider Provider) *Credentials {
+	return &Credentials{provider: provider}
+}
+
+// Get returns the cached credentials, retrieving them from the provider
+// first if there are none yet or if they are about to expire.
+func (c *Credentials) Get(ctx context.Context) (Value, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if c.value != nil && !c.expiring(c.value) {
+		return *c.value, nil
+	}
+
+	v, err := c.provider.Retrieve(ctx)
+	if err != nil {
+		return Value{}, err
+	}
+	c.value = &v
+	return v, nil
+}
+
+// Expire drops the cached credentials, so that the next call to Get
+// retrieves them again.
+func (c *Credentials) Expire() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.value = nil
+}
+
+func (c *Credentials) expiring(v *Value) bool {
+	if v.ExpiresAt.IsZero() {
+		return false
+	}
+	window := c.ExpiryWindow
+	if window == 0 {
+		window = DefaultExpiryWindow
+	}
+	return time.Until(v.ExpiresAt) <= window
+}
@@ -0,0 +1,35 @@
+/*
+Package credentials retrieves the access key (AK), secret key (SK) and
+security token used to sign requests from a chain of sources: environment
+variables, a shared credentials file, and the metadata service of the ECS
+instance the program runs on.
+
+Credentials caches what its Provider returns and retrieves it again shortly
+before temporary credentials expire. The same Credentials can be shared by a
+golangsdk.ProviderClient, through its Credentials field, and by an
+obs.ObsClient, through obs.WithCredentials.
+
+Example to Sign Requests with the Default Chain
+
+	creds := credentials.NewDefaultChain()
+
+	provider, err := openstack.NewClient("https://iam.example.com/v3")
+	if err != nil {
+		panic(err)
+	}
+	provider.Credentials = creds
+
+	err = openstack.Authenticate(provider, golangsdk.AKSKAuthOptions{
+		IdentityEndpoint: "https://iam.example.com/v3",
+		ProjectId:        "{project_id}",
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	obsClient, err := obs.New("", "", "https://obs.example.com", obs.WithCredentials(creds))
+	if err != nil {
+		panic(err)
+	}
+*/
+package credentials
@@ -0,0 +1,28 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// EnvProviderName is the Source of credentials read from the environment.
+const EnvProviderName = "EnvProvider"
+
+// EnvProvider reads credentials from the OS_ACCESS_KEY, OS_SECRET_KEY and
+// OS_SECURITY_TOKEN environment variables.
+type EnvProvider struct{}
+
+// Retrieve implements Provider.
+func (p *EnvProvider) Retrieve(ctx context.Context) (Value, error) {
+	v := Value{
+		AccessKey:     os.Getenv("OS_ACCESS_KEY"),
+		SecretKey:     os.Getenv("OS_SECRET_KEY"),
+		SecurityToken: os.Getenv("OS_SECURITY_TOKEN"),
+		Source:        EnvProviderName,
+	}
+	if v.AccessKey == "" || v.SecretKey == "" {
+		return Value{}, fmt.Errorf("%s: OS_ACCESS_KEY or OS_SECRET_KEY is not set", EnvProviderName)
+	}
+	return v, nil
+}
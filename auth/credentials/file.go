@@ -0,0 +1,117 @@
+package credentials
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+// FileProviderName is the Source of credentials read from a shared
+// credentials file.
+const FileProviderName = "FileProvider"
+
+// FileProvider reads credentials from a profile of a shared credentials
+// file, in INI format:
+//
+//	[default]
+//	access_key = {access_key}
+//	secret_key = {secret_key}
+//
+//	[temporary]
+//	access_key = {access_key}
+//	secret_key = {secret_key}
+//	security_token = {security_token}
+type FileProvider struct {
+	// Filename is the path of the file. It defaults to the
+	// OS_SHARED_CREDENTIALS_FILE environment variable, then
+	// ~/.config/openstack/credentials.
+	Filename string
+
+	// Profile is the section of the file to read. It defaults to the
+	// OS_PROFILE environment variable, then "default".
+	Profile string
+}
+
+// Retrieve implements Provider.
+func (p *FileProvider) Retrieve(ctx context.Context) (Value, error) {
+	filename, err := p.filename()
+	if err != nil {
+		return Value{}, err
+	}
+
+	profile := p.Profile
+	if profile == "" {
+		profile = os.Getenv("OS_PROFILE")
+	}
+	if profile == "" {
+		profile = "default"
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return Value{}, fmt.Errorf("%s: %s", FileProviderName, err)
+	}
+	defer f.Close()
+
+	keys := make(map[string]string)
+	found := false
+	section := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			found = found || section == profile
+			continue
+		}
+		if section != profile {
+			continue
+		}
+		if i := strings.Index(line, "="); i > 0 {
+			keys[strings.TrimSpace(line[:i])] = strings.TrimSpace(line[i+1:])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Value{}, fmt.Errorf("%s: %s", FileProviderName, err)
+	}
+
+	if !found {
+		return Value{}, fmt.Errorf("%s: profile %s not found in %s", FileProviderName, profile, filename)
+	}
+
+	v := Value{
+		AccessKey:     keys["access_key"],
+		SecretKey:     keys["secret_key"],
+		SecurityToken: keys["security_token"],
+		Source:        FileProviderName,
+	}
+	if v.AccessKey == "" || v.SecretKey == "" {
+		return Value{}, fmt.Errorf("%s: profile %s in %s has no access_key or secret_key", FileProviderName, profile, filename)
+	}
+	return v, nil
+}
+
+func (p *FileProvider) filename() (string, error) {
+	if p.Filename != "" {
+		return p.Filename, nil
+	}
+	if f := os.Getenv("OS_SHARED_CREDENTIALS_FILE"); f != "" {
+		return f, nil
+	}
+
+	home := os.Getenv("HOME")
+	if u, err := user.Current(); err == nil && u.HomeDir != "" {
+		home = u.HomeDir
+	}
+	if home == "" {
+		return "", fmt.Errorf("%s: unable to find the home directory", FileProviderName)
+	}
+	return filepath.Join(home, ".config", "openstack", "credentials"), nil
+}
@@ -0,0 +1,98 @@
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MetadataProviderName is the Source of credentials retrieved from the
+// instance metadata service.
+const MetadataProviderName = "MetadataProvider"
+
+// DefaultMetadataURL is the base URL of the instance metadata service of
+// ECS instances.
+const DefaultMetadataURL = "http://169.254.169.254"
+
+// metadataTimeout bounds a request to the metadata service when the context
+// has no deadline, so that the provider fails fast outside of an instance.
+const metadataTimeout = 5 * time.Second
+
+// MetadataProvider retrieves the temporary credentials of the agency
+// attached to the ECS instance it runs on, from the instance metadata
+// service.
+type MetadataProvider struct {
+	// BaseURL is the base URL of the metadata service. It defaults to
+	// DefaultMetadataURL.
+	BaseURL string
+
+	// HTTPClient is the client used to reach the metadata service. It
+	// defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+type metadataResponse struct {
+	Credential struct {
+		Access        string `json:"access"`
+		Secret        string `json:"secret"`
+		SecurityToken string `json:"securitytoken"`
+		ExpiresAt     string `json:"expires_at"`
+	} `json:"credential"`
+}
+
+// Retrieve implements Provider.
+func (p *MetadataProvider) Retrieve(ctx context.Context) (Value, error) {
+	base := p.BaseURL
+	if base == "" {
+		base = DefaultMetadataURL
+	}
+	client := p.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, metadataTimeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequest("GET", strings.TrimRight(base, "/")+"/openstack/latest/securitykey", nil)
+	if err != nil {
+		return Value{}, err
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return Value{}, fmt.Errorf("%s: %s", MetadataProviderName, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Value{}, fmt.Errorf("%s: unexpected response code %d", MetadataProviderName, resp.StatusCode)
+	}
+
+	var r metadataResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return Value{}, fmt.Errorf("%s: %s", MetadataProviderName, err)
+	}
+
+	v := Value{
+		AccessKey:     r.Credential.Access,
+		SecretKey:     r.Credential.Secret,
+		SecurityToken: r.Credential.SecurityToken,
+		Source:        MetadataProviderName,
+	}
+	if v.AccessKey == "" || v.SecretKey == "" {
+		return Value{}, fmt.Errorf("%s: no credentials in response", MetadataProviderName)
+	}
+	if r.Credential.ExpiresAt != "" {
+		v.ExpiresAt, err = time.Parse(time.RFC3339, r.Credential.ExpiresAt)
+		if err != nil {
+			return Value{}, fmt.Errorf("%s: %s", MetadataProviderName, err)
+		}
+	}
+	return v, nil
+}
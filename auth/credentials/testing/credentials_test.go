@@ -0,0 +1,142 @@
+package testing
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/huaweicloud/golangsdk/auth/credentials"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+func TestEnvProvider(t *testing.T) {
+	os.Setenv("OS_ACCESS_KEY", "env-access")
+	os.Setenv("OS_SECRET_KEY", "env-secret")
+	defer os.Unsetenv("OS_ACCESS_KEY")
+	defer os.Unsetenv("OS_SECRET_KEY")
+
+	v, err := (&credentials.EnvProvider{}).Retrieve(context.Background())
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, credentials.Value{
+		AccessKey: "env-access",
+		SecretKey: "env-secret",
+		Source:    credentials.EnvProviderName,
+	}, v)
+
+	os.Unsetenv("OS_SECRET_KEY")
+	_, err = (&credentials.EnvProvider{}).Retrieve(context.Background())
+	if err == nil {
+		t.Fatal("expected an error without OS_SECRET_KEY")
+	}
+}
+
+func writeCredentialsFile(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "credentials")
+	th.AssertNoErr(t, err)
+	filename := filepath.Join(dir, "credentials")
+	th.AssertNoErr(t, ioutil.WriteFile(filename, []byte(CredentialsFile), 0600))
+	return filename, func() { os.RemoveAll(dir) }
+}
+
+func TestFileProvider(t *testing.T) {
+	filename, cleanup := writeCredentialsFile(t)
+	defer cleanup()
+
+	v, err := (&credentials.FileProvider{Filename: filename}).Retrieve(context.Background())
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "default-access", v.AccessKey)
+	th.CheckEquals(t, "default-secret", v.SecretKey)
+	th.CheckEquals(t, "", v.SecurityToken)
+
+	v, err = (&credentials.FileProvider{Filename: filename, Profile: "temporary"}).Retrieve(context.Background())
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, credentials.Value{
+		AccessKey:     "temporary-access",
+		SecretKey:     "temporary-secret",
+		SecurityToken: "temporary-token",
+		Source:        credentials.FileProviderName,
+	}, v)
+
+	_, err = (&credentials.FileProvider{Filename: filename, Profile: "missing"}).Retrieve(context.Background())
+	if err == nil {
+		t.Fatal("expected an error for a missing profile")
+	}
+}
+
+func TestMetadataProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleSecurityKeySuccessfully(t)
+
+	v, err := (&credentials.MetadataProvider{BaseURL: th.Endpoint()}).Retrieve(context.Background())
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, credentials.Value{
+		AccessKey:     "metadata-access",
+		SecretKey:     "metadata-secret",
+		SecurityToken: "metadata-token",
+		ExpiresAt:     time.Date(2099, 1, 2, 3, 4, 5, 0, time.UTC),
+		Source:        credentials.MetadataProviderName,
+	}, v)
+}
+
+func TestChainProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleSecurityKeySuccessfully(t)
+
+	chain := credentials.NewChainProvider(
+		&credentials.EnvProvider{},
+		&credentials.FileProvider{Filename: "/nonexistent/credentials"},
+		&credentials.MetadataProvider{BaseURL: th.Endpoint()},
+	)
+	v, err := chain.Retrieve(context.Background())
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, credentials.MetadataProviderName, v.Source)
+
+	chain.Providers = chain.Providers[:2]
+	_, err = chain.Retrieve(context.Background())
+	chainErr, ok := err.(credentials.ErrNoValidProvider)
+	if !ok {
+		t.Fatalf("expected ErrNoValidProvider, got %T: %v", err, err)
+	}
+	th.CheckEquals(t, 2, len(chainErr.Errors))
+}
+
+type countingProvider struct {
+	retrieved int
+	expiresIn time.Duration
+}
+
+func (p *countingProvider) Retrieve(ctx context.Context) (credentials.Value, error) {
+	p.retrieved++
+	return credentials.Value{
+		AccessKey: "access",
+		SecretKey: "secret",
+		ExpiresAt: time.Now().Add(p.expiresIn),
+	}, nil
+}
+
+func TestCredentialsRefresh(t *testing.T) {
+	p := &countingProvider{expiresIn: time.Hour}
+	creds := credentials.NewCredentials(p)
+
+	for i := 0; i < 3; i++ {
+		_, err := creds.Get(context.Background())
+		th.AssertNoErr(t, err)
+	}
+	th.CheckEquals(t, 1, p.retrieved)
+
+	creds.ExpiryWindow = 2 * time.Hour
+	_, err := creds.Get(context.Background())
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 2, p.retrieved)
+
+	creds.ExpiryWindow = 0
+	creds.Expire()
+	_, err = creds.Get(context.Background())
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 3, p.retrieved)
+}
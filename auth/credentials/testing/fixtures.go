@@ -0,0 +1,45 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+// CredentialsFile is a shared credentials file with two profiles.
+const CredentialsFile = `
+# Permanent credentials
+[default]
+access_key = default-access
+secret_key = default-secret
+
+[temporary]
+access_key = temporary-access
+secret_key = temporary-secret
+security_token = temporary-token
+`
+
+// SecurityKeyOutput is the response of the metadata service.
+const SecurityKeyOutput = `
+{
+    "credential": {
+        "access": "metadata-access",
+        "secret": "metadata-secret",
+        "securitytoken": "metadata-token",
+        "expires_at": "2099-01-02T03:04:05.000000Z"
+    }
+}
+`
+
+// HandleSecurityKeySuccessfully sets up the test server to respond to a
+// security key request of the metadata service.
+func HandleSecurityKeySuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/openstack/latest/securitykey", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, SecurityKeyOutput)
+	})
+}
@@ -0,0 +1,51 @@
+package golangsdk
+
+// AuthOptionsProvider is satisfied by every set of options that a provider's
+// AuthenticatedClient function accepts, such as AuthOptions and AKSKAuthOptions.
+type AuthOptionsProvider interface {
+	GetIdentityEndpoint() string
+}
+
+/*
+AKSKAuthOptions stores the access key (AK) and secret key (SK) used to sign
+requests with the SDK-HMAC-SHA256 algorithm instead of a Keystone token.
+
+An example of manually providing AK/SK credentials:
+
+	opts := golangsdk.AKSKAuthOptions{
+		IdentityEndpoint: "https://iam.example.com/v3",
+		ProjectId:        "{project_id}",
+		AccessKey:        "{access_key}",
+		SecretKey:        "{secret_key}",
+	}
+
+	provider, err := openstack.AuthenticatedClient(opts)
+*/
+type AKSKAuthOptions struct {
+	// IdentityEndpoint specifies the HTTP endpoint of the Identity v3 API. It is
+	// used to look up the service catalog, never to issue a token.
+	IdentityEndpoint string `json:"-"`
+
+	// ProjectId is the ID of the project all requests are scoped to. It is sent
+	// in the X-Project-Id header and used to build project-scoped URLs.
+	ProjectId string
+
+	// DomainID is the ID of the domain for global services such as IAM. It is
+	// sent in the X-Domain-Id header.
+	DomainID string
+
+	// AccessKey is the access key ID (AK).
+	AccessKey string
+
+	// SecretKey is the secret access key (SK).
+	SecretKey string
+
+	// SecurityToken is the security token that comes with temporary AK/SK
+	// credentials. It is sent in the X-Security-Token header.
+	SecurityToken string
+}
+
+// GetIdentityEndpoint implements the AuthOptionsProvider interface.
+func (opts AKSKAuthOptions) GetIdentityEndpoint() string {
+	return opts.IdentityEndpoint
+}
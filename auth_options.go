@@ -81,6 +81,24 @@ type AuthOptions struct {
 	// TokenID allows users to authenticate (possibly as another user) with an
 	// authentication token ID.
 	TokenID string `json:"-"`
+
+	// Passcode is the current TOTP passcode of the user, for accounts protected
+	// by multi-factor authentication. It is sent with the Password, or alone
+	// if no Password is given, and identifies the user in the same way.
+	// A passcode is only valid for a short time, so re-authentication with
+	// AllowReauth will fail once it has expired.
+	Passcode string `json:"-"`
+
+	// ApplicationCredentialID or ApplicationCredentialName, together with
+	// ApplicationCredentialSecret, authenticate with an Identity V3
+	// application credential instead of a Password or TokenID. A credential
+	// given by name also needs the user that owns it: either UserID or a
+	// combination of Username and DomainID or DomainName. The token is scoped
+	// to the project the credential was created in, so the TenantID and
+	// TenantName options are ignored.
+	ApplicationCredentialID     string `json:"-"`
+	ApplicationCredentialName   string `json:"-"`
+	ApplicationCredentialSecret string `json:"-"`
 }
 
 // ToTokenV2CreateMap allows AuthOptions to satisfy the AuthOptionsBuilder
@@ -131,7 +149,8 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]interface{}) (map[s
 	type userReq struct {
 		ID       *string    `json:"id,omitempty"`
 		Name     *string    `json:"name,omitempty"`
-		Password string     `json:"password"`
+		Password string     `json:"password,omitempty"`
+		Passcode string     `json:"passcode,omitempty"`
 		Domain   *domainReq `json:"domain,omitempty"`
 	}
 
@@ -139,14 +158,27 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]interface{}) (map[s
 		User userReq `json:"user"`
 	}
 
+	type totpReq struct {
+		User userReq `json:"user"`
+	}
+
 	type tokenReq struct {
 		ID string `json:"id"`
 	}
 
+	type applicationCredentialReq struct {
+		ID     *string  `json:"id,omitempty"`
+		Name   *string  `json:"name,omitempty"`
+		User   *userReq `json:"user,omitempty"`
+		Secret string   `json:"secret"`
+	}
+
 	type identityReq struct {
-		Methods  []string     `json:"methods"`
-		Password *passwordReq `json:"password,omitempty"`
-		Token    *tokenReq    `json:"token,omitempty"`
+		Methods               []string                  `json:"methods"`
+		Password              *passwordReq              `json:"password,omitempty"`
+		Totp                  *totpReq                  `json:"totp,omitempty"`
+		Token                 *tokenReq                 `json:"token,omitempty"`
+		ApplicationCredential *applicationCredentialReq `json:"application_credential,omitempty"`
 	}
 
 	type authReq struct {
@@ -157,11 +189,95 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]interface{}) (map[s
 		Auth authReq `json:"auth"`
 	}
 
+	// user identifies the user for the password and totp methods, and the
+	// owner of an application credential given by name.
+	user := func() (*userReq, error) {
+		// At least one of Username and UserID must be specified.
+		if opts.Username == "" && opts.UserID == "" {
+			return nil, ErrUsernameOrUserID{}
+		}
+
+		if opts.Username != "" {
+			// If Username is provided, UserID may not be provided.
+			if opts.UserID != "" {
+				return nil, ErrUsernameOrUserID{}
+			}
+
+			// Either DomainID or DomainName must also be specified.
+			if opts.DomainID == "" && opts.DomainName == "" {
+				return nil, ErrDomainIDOrDomainName{}
+			}
+
+			if opts.DomainID != "" {
+				if opts.DomainName != "" {
+					return nil, ErrDomainIDOrDomainName{}
+				}
+
+				// Username with a DomainID.
+				return &userReq{Name: &opts.Username, Domain: &domainReq{ID: &opts.DomainID}}, nil
+			}
+
+			// Username with a DomainName.
+			return &userReq{Name: &opts.Username, Domain: &domainReq{Name: &opts.DomainName}}, nil
+		}
+
+		// If UserID is specified, neither DomainID nor DomainName may be.
+		if opts.DomainID != "" {
+			return nil, ErrDomainIDWithUserID{}
+		}
+		if opts.DomainName != "" {
+			return nil, ErrDomainNameWithUserID{}
+		}
+
+		return &userReq{ID: &opts.UserID}, nil
+	}
+
 	// Populate the request structure based on the provided arguments. Create and return an error
 	// if insufficient or incompatible information is present.
 	var req request
 
-	if opts.Password == "" {
+	if opts.ApplicationCredentialID != "" || opts.ApplicationCredentialName != "" {
+		// An application credential replaces every other method.
+		if opts.Password != "" {
+			return nil, ErrPasswordWithAppCred{}
+		}
+		if opts.TokenID != "" {
+			return nil, ErrTokenIDWithAppCred{}
+		}
+		if opts.Passcode != "" {
+			return nil, ErrPasscodeWithAppCred{}
+		}
+		if opts.ApplicationCredentialID != "" && opts.ApplicationCredentialName != "" {
+			return nil, ErrAppCredIDOrAppCredName{}
+		}
+		if opts.ApplicationCredentialSecret == "" {
+			return nil, ErrAppCredMissingSecret{}
+		}
+
+		req.Auth.Identity.Methods = []string{"application_credential"}
+
+		if opts.ApplicationCredentialID != "" {
+			// The ID alone identifies the credential.
+			req.Auth.Identity.ApplicationCredential = &applicationCredentialReq{
+				ID:     &opts.ApplicationCredentialID,
+				Secret: opts.ApplicationCredentialSecret,
+			}
+		} else {
+			// A name is only unique for its user.
+			if opts.Username == "" && opts.UserID == "" {
+				return nil, ErrAppCredNameWithoutUser{}
+			}
+			u, err := user()
+			if err != nil {
+				return nil, err
+			}
+			req.Auth.Identity.ApplicationCredential = &applicationCredentialReq{
+				Name:   &opts.ApplicationCredentialName,
+				User:   u,
+				Secret: opts.ApplicationCredentialSecret,
+			}
+		}
+	} else if opts.Password == "" && opts.Passcode == "" {
 		if opts.TokenID != "" {
 			// Because we aren't using password authentication, it's an error to also provide any of the user-based authentication
 			// parameters.
@@ -188,65 +304,30 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]interface{}) (map[s
 			return nil, ErrMissingPassword{}
 		}
 	} else {
-		// Password authentication.
-		req.Auth.Identity.Methods = []string{"password"}
-
-		// At least one of Username and UserID must be specified.
-		if opts.Username == "" && opts.UserID == "" {
-			return nil, ErrUsernameOrUserID{}
+		// A passcode completes a password, it does not complete a token.
+		if opts.Password == "" && opts.TokenID != "" {
+			return nil, ErrPasscodeWithToken{}
 		}
 
-		if opts.Username != "" {
-			// If Username is provided, UserID may not be provided.
-			if opts.UserID != "" {
-				return nil, ErrUsernameOrUserID{}
-			}
-
-			// Either DomainID or DomainName must also be specified.
-			if opts.DomainID == "" && opts.DomainName == "" {
-				return nil, ErrDomainIDOrDomainName{}
-			}
-
-			if opts.DomainID != "" {
-				if opts.DomainName != "" {
-					return nil, ErrDomainIDOrDomainName{}
-				}
-
-				// Configure the request for Username and Password authentication with a DomainID.
-				req.Auth.Identity.Password = &passwordReq{
-					User: userReq{
-						Name:     &opts.Username,
-						Password: opts.Password,
-						Domain:   &domainReq{ID: &opts.DomainID},
-					},
-				}
-			}
-
-			if opts.DomainName != "" {
-				// Configure the request for Username and Password authentication with a DomainName.
-				req.Auth.Identity.Password = &passwordReq{
-					User: userReq{
-						Name:     &opts.Username,
-						Password: opts.Password,
-						Domain:   &domainReq{Name: &opts.DomainName},
-					},
-				}
-			}
+		u, err := user()
+		if err != nil {
+			return nil, err
 		}
 
-		if opts.UserID != "" {
-			// If UserID is specified, neither DomainID nor DomainName may be.
-			if opts.DomainID != "" {
-				return nil, ErrDomainIDWithUserID{}
-			}
-			if opts.DomainName != "" {
-				return nil, ErrDomainNameWithUserID{}
-			}
+		if opts.Password != "" {
+			// Password authentication.
+			password := *u
+			password.Password = opts.Password
+			req.Auth.Identity.Methods = append(req.Auth.Identity.Methods, "password")
+			req.Auth.Identity.Password = &passwordReq{User: password}
+		}
 
-			// Configure the request for UserID and Password authentication.
-			req.Auth.Identity.Password = &passwordReq{
-				User: userReq{ID: &opts.UserID, Password: opts.Password},
-			}
+		if opts.Passcode != "" {
+			// TOTP authentication, on its own or as a second factor.
+			totp := *u
+			totp.Passcode = opts.Passcode
+			req.Auth.Identity.Methods = append(req.Auth.Identity.Methods, "totp")
+			req.Auth.Identity.Totp = &totpReq{User: totp}
 		}
 	}
 
@@ -263,6 +344,10 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]interface{}) (map[s
 }
 
 func (opts *AuthOptions) ToTokenV3ScopeMap() (map[string]interface{}, error) {
+	// An application credential is bound to its project and may not be scoped.
+	if opts.ApplicationCredentialID != "" || opts.ApplicationCredentialName != "" {
+		return nil, nil
+	}
 
 	var scope struct {
 		ProjectID   string
@@ -352,3 +437,8 @@ func (opts *AuthOptions) ToTokenV3ScopeMap() (map[string]interface{}, error) {
 func (opts AuthOptions) CanReauth() bool {
 	return opts.AllowReauth
 }
+
+// GetIdentityEndpoint implements the AuthOptionsProvider interface.
+func (opts AuthOptions) GetIdentityEndpoint() string {
+	return opts.IdentityEndpoint
+}
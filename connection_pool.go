@@ -0,0 +1,59 @@
+package golangsdk
+
+import (
+	"net/http"
+	"time"
+)
+
+// ConnectionPoolOpts tunes how a ProviderClient reuses HTTP connections.
+// Zero values keep the setting of the transport being configured.
+type ConnectionPoolOpts struct {
+	// MaxIdleConns caps the number of idle connections across all hosts.
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost caps the number of idle connections kept per host.
+	// The net/http default of 2 is low for clients that page through large
+	// collections or issue concurrent calls to the same endpoint.
+	MaxIdleConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection stays in the pool.
+	IdleConnTimeout time.Duration
+
+	// TLSHandshakeTimeout limits the time spent on a TLS handshake.
+	TLSHandshakeTimeout time.Duration
+}
+
+// ConfigureConnectionPool applies opts to the transport of the client's
+// HTTPClient. If the transport is an *http.Transport (or unset, in which case
+// http.DefaultTransport is used), a copy of it is configured so that settings
+// such as TLS and proxy configuration are kept and other clients sharing the
+// original transport are not affected. Custom transports are left untouched
+// and false is returned.
+func (client *ProviderClient) ConfigureConnectionPool(opts ConnectionPoolOpts) bool {
+	rt := client.HTTPClient.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	base, ok := rt.(*http.Transport)
+	if !ok {
+		return false
+	}
+
+	transport := base.Clone()
+	if opts.MaxIdleConns > 0 {
+		transport.MaxIdleConns = opts.MaxIdleConns
+	}
+	if opts.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	}
+	if opts.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = opts.IdleConnTimeout
+	}
+	if opts.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = opts.TLSHandshakeTimeout
+	}
+
+	client.HTTPClient.Transport = transport
+	return true
+}
@@ -1,5 +1,7 @@
 package golangsdk
 
+import "strings"
+
 // Availability indicates to whom a specific service endpoint is accessible:
 // the internet at large, internal networks only, or only to administrators.
 // Different identity services use different terminology for these. Identity v2
@@ -51,6 +53,46 @@ type EndpointOpts struct {
 	// Availability is not required, and defaults to AvailabilityPublic. Not all
 	// providers or services offer all Availability options.
 	Availability Availability
+
+	// Overrides [optional] maps service types (e.g., "network", "nat") to
+	// the endpoint of the service, in the form the service catalog would list
+	// it. The service catalog is not searched for a service type that has an
+	// override.
+	Overrides map[string]string
+
+	// Template [optional] builds the endpoint of a service that is missing
+	// from the service catalog, such as "https://{service}.{region}.{domain}/".
+	// {service} is replaced by the service type, {region} by Region and
+	// {domain} by Domain.
+	Template string
+
+	// Domain [optional] is the domain name that replaces {domain} in
+	// Template (e.g., "myhuaweicloud.com").
+	Domain string
+}
+
+// TemplateEndpoint builds the endpoint of the service type t from Template.
+// It is an error for Template to use a placeholder whose value is empty.
+func (eo EndpointOpts) TemplateEndpoint(t string) (string, error) {
+	values := []struct {
+		placeholder, argument, value string
+	}{
+		{"{service}", "Type", t},
+		{"{region}", "Region", eo.Region},
+		{"{domain}", "Domain", eo.Domain},
+	}
+
+	url := eo.Template
+	for _, v := range values {
+		if !strings.Contains(url, v.placeholder) {
+			continue
+		}
+		if v.value == "" {
+			return "", ErrMissingInput{Argument: v.argument}
+		}
+		url = strings.Replace(url, v.placeholder, v.value, -1)
+	}
+	return NormalizeURL(url), nil
 }
 
 /*
@@ -1,6 +1,13 @@
 package golangsdk
 
-import "fmt"
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+)
 
 // BaseError is an error type that all other error types embed.
 type BaseError struct {
@@ -52,6 +59,10 @@ type ErrUnexpectedResponseCode struct {
 	Expected []int
 	Actual   int
 	Body     []byte
+
+	// APIError is the error reported by the service in Body. It is also
+	// reachable with errors.As.
+	APIError *APIError
 }
 
 func (e ErrUnexpectedResponseCode) Error() string {
@@ -62,6 +73,162 @@ func (e ErrUnexpectedResponseCode) Error() string {
 	return e.choseErrString()
 }
 
+// Unwrap returns the APIError of e, if any.
+func (e ErrUnexpectedResponseCode) Unwrap() error {
+	if e.APIError == nil {
+		return nil
+	}
+	return e.APIError
+}
+
+// Is reports whether target is the ErrDefault type of the response code of
+// e, so that errors.Is(err, golangsdk.ErrDefault404{}) holds for any error
+// returned on a 404 response.
+func (e ErrUnexpectedResponseCode) Is(target error) bool {
+	code := defaultErrCode(target)
+	return code != 0 && code == e.Actual
+}
+
+// APIError is the error reported by a cloud service in the body of a failed
+// response, such as {"error_code": "VPC.0101", "error_msg": "..."}.
+type APIError struct {
+	BaseError
+
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Code is the error code of the service, such as "VPC.0101". It is empty
+	// when the body holds no recognized error.
+	Code string
+
+	// Message is the error message of the service.
+	Message string
+
+	// RequestID identifies the request to the support of the service. It
+	// comes from the X-Request-Id header or, failing that, from the body.
+	RequestID string
+}
+
+func (e *APIError) Error() string {
+	if e.Info != "" {
+		return e.Info
+	}
+	msg := fmt.Sprintf("Request failed with HTTP response code %d", e.StatusCode)
+	if e.Code != "" {
+		msg += fmt.Sprintf(": [%s]", e.Code)
+	}
+	if e.Message != "" {
+		msg += " " + e.Message
+	}
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request ID %s)", e.RequestID)
+	}
+	return msg
+}
+
+// Is reports whether target is the ErrDefault type of the response code of
+// e.
+func (e *APIError) Is(target error) bool {
+	code := defaultErrCode(target)
+	return code != 0 && code == e.StatusCode
+}
+
+// defaultErrCode returns the HTTP response code of the ErrDefault type of
+// err, or 0 if err is of no such type.
+func defaultErrCode(err error) int {
+	switch err.(type) {
+	case ErrDefault400, *ErrDefault400:
+		return http.StatusBadRequest
+	case ErrDefault401, *ErrDefault401:
+		return http.StatusUnauthorized
+	case ErrDefault404, *ErrDefault404:
+		return http.StatusNotFound
+	case ErrDefault405, *ErrDefault405:
+		return http.StatusMethodNotAllowed
+	case ErrDefault408, *ErrDefault408:
+		return http.StatusRequestTimeout
+	case ErrDefault429, *ErrDefault429:
+		return http.StatusTooManyRequests
+	case ErrDefault500, *ErrDefault500:
+		return http.StatusInternalServerError
+	case ErrDefault503, *ErrDefault503:
+		return http.StatusServiceUnavailable
+	}
+	return 0
+}
+
+// ParseAPIError builds the APIError of a failed response from its status
+// code, header and body. The body may hold any of the error formats of the
+// cloud:
+//
+//	{"error_code": "VPC.0101", "error_msg": "..."}
+//	{"error": {"code": "...", "message": "..."}}
+//	{"itemNotFound": {"code": 404, "message": "..."}}
+//	<Error><Code>NoSuchKey</Code><Message>...</Message><RequestId>...</RequestId></Error>
+func ParseAPIError(statusCode int, header http.Header, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: statusCode,
+		RequestID:  header.Get("X-Request-Id"),
+	}
+
+	var requestID string
+	trimmed := bytes.TrimSpace(body)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		var m map[string]json.RawMessage
+		if json.Unmarshal(trimmed, &m) != nil {
+			break
+		}
+		if _, ok := m["error_code"]; ok {
+			e.Code = rawString(m["error_code"])
+			e.Message = rawString(m["error_msg"])
+			requestID = rawString(m["request_id"])
+			break
+		}
+		// OpenStack services wrap the error in an object named after it,
+		// such as "error" or "itemNotFound".
+		for _, v := range m {
+			var inner struct {
+				Code    json.RawMessage `json:"code"`
+				Message string          `json:"message"`
+			}
+			if json.Unmarshal(v, &inner) == nil && (inner.Code != nil || inner.Message != "") {
+				e.Code, e.Message = rawString(inner.Code), inner.Message
+				break
+			}
+		}
+		requestID = rawString(m["request_id"])
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		var x struct {
+			XMLName   xml.Name `xml:"Error"`
+			Code      string   `xml:"Code"`
+			Message   string   `xml:"Message"`
+			RequestID string   `xml:"RequestId"`
+		}
+		if xml.Unmarshal(trimmed, &x) == nil {
+			e.Code, e.Message, requestID = x.Code, x.Message, x.RequestID
+		}
+	}
+
+	if e.RequestID == "" {
+		e.RequestID = requestID
+	}
+	return e
+}
+
+// rawString returns the JSON string or number raw as a string.
+func rawString(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var n json.Number
+	if json.Unmarshal(raw, &n) == nil {
+		return n.String()
+	}
+	return ""
+}
+
 // ErrDefault400 is the default error type returned on a 400 HTTP response code.
 type ErrDefault400 struct {
 	ErrUnexpectedResponseCode
@@ -178,8 +345,11 @@ type Err503er interface {
 }
 
 // ErrTimeOut is the error type returned when an operations times out.
+// When returned by a Waiter, LastState holds the last state observed, if any.
 type ErrTimeOut struct {
 	BaseError
+	Timeout   time.Duration
+	LastState interface{}
 }
 
 func (e ErrTimeOut) Error() string {
@@ -198,6 +368,12 @@ func (e ErrUnableToReauthenticate) Error() string {
 	return e.choseErrString()
 }
 
+// Unwrap returns the error of the request that triggered the
+// reauthentication.
+func (e ErrUnableToReauthenticate) Unwrap() error {
+	return e.ErrOriginal
+}
+
 // ErrErrorAfterReauthentication is the error type returned when reauthentication
 // succeeds, but an error occurs afterword (usually an HTTP error).
 type ErrErrorAfterReauthentication struct {
@@ -210,6 +386,11 @@ func (e ErrErrorAfterReauthentication) Error() string {
 	return e.choseErrString()
 }
 
+// Unwrap returns the error of the request after reauthentication.
+func (e ErrErrorAfterReauthentication) Unwrap() error {
+	return e.ErrOriginal
+}
+
 // ErrServiceNotFound is returned when no service in a service catalog matches
 // the provided EndpointOpts. This is generally returned by provider service
 // factory methods like "NewComputeV2()" and can mean that a service is not
@@ -287,6 +468,10 @@ func redundantWithUserID(attribute string) string {
 	return fmt.Sprintf("%s may not be provided when authenticating with a UserID", attribute)
 }
 
+func redundantWithAppCredErr(attribute string) string {
+	return fmt.Sprintf("%s may not be provided when authenticating with an application credential", attribute)
+}
+
 // ErrAPIKeyProvided indicates that an APIKey was provided but can't be used.
 type ErrAPIKeyProvided struct{ BaseError }
 
@@ -372,6 +557,55 @@ func (e ErrMissingPassword) Error() string {
 	return "You must provide a password to authenticate"
 }
 
+// ErrPasscodeWithToken indicates that a TOTP Passcode was provided with a TokenID but no Password.
+type ErrPasscodeWithToken struct{ BaseError }
+
+func (e ErrPasscodeWithToken) Error() string {
+	return redundantWithTokenErr("Passcode")
+}
+
+// ErrAppCredIDOrAppCredName indicates that both an ApplicationCredentialID and an ApplicationCredentialName were provided.
+type ErrAppCredIDOrAppCredName struct{ BaseError }
+
+func (e ErrAppCredIDOrAppCredName) Error() string {
+	return "You must provide at most one of ApplicationCredentialID or ApplicationCredentialName"
+}
+
+// ErrAppCredMissingSecret indicates that an application credential was provided without its secret.
+type ErrAppCredMissingSecret struct{ BaseError }
+
+func (e ErrAppCredMissingSecret) Error() string {
+	return "You must provide an ApplicationCredentialSecret to authenticate with an application credential"
+}
+
+// ErrAppCredNameWithoutUser indicates that an ApplicationCredentialName was provided without the user that owns it.
+type ErrAppCredNameWithoutUser struct{ BaseError }
+
+func (e ErrAppCredNameWithoutUser) Error() string {
+	return "You must provide either UserID or Username with exactly one of DomainID or DomainName to authenticate by ApplicationCredentialName"
+}
+
+// ErrPasswordWithAppCred indicates that a Password was provided, but application credential authentication is being used instead.
+type ErrPasswordWithAppCred struct{ BaseError }
+
+func (e ErrPasswordWithAppCred) Error() string {
+	return redundantWithAppCredErr("Password")
+}
+
+// ErrTokenIDWithAppCred indicates that a TokenID was provided, but application credential authentication is being used instead.
+type ErrTokenIDWithAppCred struct{ BaseError }
+
+func (e ErrTokenIDWithAppCred) Error() string {
+	return redundantWithAppCredErr("TokenID")
+}
+
+// ErrPasscodeWithAppCred indicates that a Passcode was provided, but application credential authentication is being used instead.
+type ErrPasscodeWithAppCred struct{ BaseError }
+
+func (e ErrPasscodeWithAppCred) Error() string {
+	return redundantWithAppCredErr("Passcode")
+}
+
 // ErrScopeDomainIDOrDomainName indicates that a domain ID or Name was required in a Scope, but not present.
 type ErrScopeDomainIDOrDomainName struct{ BaseError }
 
@@ -0,0 +1,218 @@
+package golangsdk
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// APICall describes an API call made by a ProviderClient, including all of
+// its attempts.
+type APICall struct {
+	// ServiceType is the type of the ServiceClient making the call, such as
+	// "compute". It is "" for calls made directly on a ProviderClient.
+	ServiceType string
+
+	// Method and URL are those of the request.
+	Method string
+	URL    string
+
+	// Operation is the method followed by the path of the URL with the
+	// segments that look like IDs replaced by "{id}", such as
+	// "GET /v1/{id}/vpcs/{id}". Unlike URL, it is suitable as a metric label.
+	Operation string
+
+	// RequestHeader holds the headers sent with every attempt of the call.
+	// StartSpan may add to it, to propagate a trace for example.
+	RequestHeader http.Header
+
+	// StatusCode is the status of the last response, or 0 if none was
+	// received.
+	StatusCode int
+
+	// Err is the error the call returned, if any.
+	Err error
+
+	// Latency is the time the call took, including retries and waiting for
+	// the RateLimiter.
+	Latency time.Duration
+
+	// Retries is the number of attempts after the first one, whether made by
+	// the RetryPolicy or after re-authenticating.
+	Retries int
+
+	// BytesSent is the size of the request bodies sent, and BytesReceived the
+	// size of the response bodies received, over all attempts. BytesReceived
+	// only counts what was read of a body of unknown length by the time the
+	// call returned.
+	BytesSent     int64
+	BytesReceived int64
+}
+
+// Instrumentation observes the API calls of a ProviderClient, to record
+// metrics or traces. Set it on ProviderClient.Instrumentation; the
+// instrumentation subpackage provides implementations.
+type Instrumentation interface {
+	// StartSpan is called before a call is sent, with the fields of call up
+	// to RequestHeader set. The context it returns is the one the call is
+	// made with, and is passed to EndSpan.
+	StartSpan(ctx context.Context, call *APICall) context.Context
+
+	// EndSpan is called once the call returns, with every field of call set.
+	EndSpan(ctx context.Context, call *APICall)
+}
+
+// MultiInstrumentation returns an Instrumentation that calls each of list in
+// turn. EndSpan calls them in the reverse order.
+func MultiInstrumentation(list ...Instrumentation) Instrumentation {
+	return multiInstrumentation(list)
+}
+
+type multiInstrumentation []Instrumentation
+
+func (m multiInstrumentation) StartSpan(ctx context.Context, call *APICall) context.Context {
+	for _, i := range m {
+		ctx = i.StartSpan(ctx, call)
+	}
+	return ctx
+}
+
+func (m multiInstrumentation) EndSpan(ctx context.Context, call *APICall) {
+	for i := len(m) - 1; i >= 0; i-- {
+		m[i].EndSpan(ctx, call)
+	}
+}
+
+// callStats accumulates the attempts of an instrumented call.
+type callStats struct {
+	client        *ProviderClient
+	attempts      int
+	statusCode    int
+	bytesSent     int64
+	bytesReceived int64
+}
+
+type callStatsKey struct{}
+
+// callStatsFrom returns the statistics of the call of client that ctx
+// belongs to, or nil if the call isn't instrumented.
+func (client *ProviderClient) callStatsFrom(ctx context.Context) *callStats {
+	stats, _ := ctx.Value(callStatsKey{}).(*callStats)
+	if stats == nil || stats.client != client {
+		return nil
+	}
+	return stats
+}
+
+// instrument makes a call through send, reporting it to the Instrumentation
+// of the client.
+func (client *ProviderClient) instrument(ctx context.Context, method, url string, options *RequestOpts, send RequestHandler) (*http.Response, error) {
+	call := &APICall{
+		ServiceType:   serviceTypeFrom(ctx),
+		Method:        method,
+		URL:           url,
+		Operation:     method + " " + operationPath(url),
+		RequestHeader: http.Header{},
+	}
+	ctx = client.Instrumentation.StartSpan(ctx, call)
+	if len(call.RequestHeader) > 0 {
+		// Callers may share options, and their MoreHeaders, between
+		// concurrent calls: add the headers to a copy.
+		opts := *options
+		opts.MoreHeaders = make(map[string]string, len(options.MoreHeaders)+len(call.RequestHeader))
+		for k, v := range options.MoreHeaders {
+			opts.MoreHeaders[k] = v
+		}
+		for k := range call.RequestHeader {
+			opts.MoreHeaders[k] = call.RequestHeader.Get(k)
+		}
+		options = &opts
+	}
+
+	stats := &callStats{client: client}
+	start := time.Now()
+	resp, err := send(context.WithValue(ctx, callStatsKey{}, stats), method, url, options)
+
+	call.Latency = time.Since(start)
+	call.Err = err
+	call.StatusCode = stats.statusCode
+	if stats.attempts > 1 {
+		call.Retries = stats.attempts - 1
+	}
+	call.BytesSent = stats.bytesSent
+	call.BytesReceived = atomic.LoadInt64(&stats.bytesReceived)
+	client.Instrumentation.EndSpan(ctx, call)
+	return resp, err
+}
+
+// record adds an attempt of the call to the statistics, and counts the bytes
+// read from its response body.
+func (stats *callStats) record(req *http.Request, resp *http.Response) {
+	stats.attempts++
+	if req.ContentLength > 0 {
+		stats.bytesSent += req.ContentLength
+	}
+	if resp == nil {
+		return
+	}
+	stats.statusCode = resp.StatusCode
+	if resp.ContentLength > 0 {
+		stats.bytesReceived += resp.ContentLength
+	} else if resp.ContentLength < 0 && resp.Body != nil {
+		resp.Body = &countingBody{ReadCloser: resp.Body, n: &stats.bytesReceived}
+	}
+}
+
+// countingBody counts the bytes read from a response body.
+type countingBody struct {
+	io.ReadCloser
+	n *int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	atomic.AddInt64(b.n, int64(n))
+	return n, err
+}
+
+// operationPath returns the path of rawurl with the segments that look like
+// IDs replaced by "{id}".
+func operationPath(rawurl string) string {
+	path := rawurl
+	if u, err := url.Parse(rawurl); err == nil {
+		path = u.EscapedPath()
+	}
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if isIDSegment(s) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isIDSegment reports whether a path segment is a number, or a UUID or other
+// hexadecimal ID of at least 16 characters.
+func isIDSegment(s string) bool {
+	if s == "" {
+		return false
+	}
+	digits, hex := 0, true
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			digits++
+		case c >= 'a' && c <= 'f', c >= 'A' && c <= 'F', c == '-':
+		default:
+			hex = false
+		}
+	}
+	if digits == len(s) {
+		return true
+	}
+	return hex && digits > 0 && len(s) >= 16
+}
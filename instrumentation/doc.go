@@ -0,0 +1,29 @@
+/*
+Package instrumentation provides implementations of golangsdk.Instrumentation
+that record the API calls of a ProviderClient as Prometheus metrics and
+propagate them as W3C Trace Context spans.
+
+Example to expose metrics
+
+	metrics := instrumentation.NewMetrics()
+	provider.Instrumentation = metrics
+
+	http.Handle("/metrics", metrics)
+
+Example to propagate traces
+
+	tracer := &instrumentation.Tracer{
+		OnEnd: func(span instrumentation.Span) {
+			log.Printf("%s %s took %s", span.TraceID, span.Name, span.End.Sub(span.Start))
+		},
+	}
+	provider.Instrumentation = golangsdk.MultiInstrumentation(metrics, tracer)
+
+	// Continue the trace of an incoming request.
+	ctx, err := instrumentation.ContextWithTraceParent(r.Context(), r.Header.Get("traceparent"))
+	if err != nil {
+		ctx = r.Context()
+	}
+	allPages, err := vpcs.List(client, vpcs.ListOpts{}).WithContext(ctx).AllPages()
+*/
+package instrumentation
@@ -0,0 +1,215 @@
+package instrumentation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/huaweicloud/golangsdk"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, of the latency histogram
+// buckets used when Metrics.Buckets is empty.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Metrics is a golangsdk.Instrumentation that counts the API calls of a
+// ProviderClient by service, operation and status, and exposes them in the
+// Prometheus text format:
+//
+//	<namespace>_requests_total{service,operation,status}
+//	<namespace>_request_duration_seconds{service,operation}
+//	<namespace>_request_retries_total{service,operation}
+//	<namespace>_request_sent_bytes_total{service,operation}
+//	<namespace>_request_received_bytes_total{service,operation}
+//
+// The status label is the HTTP status of the last response, or "error" if
+// none was received. Metrics implements http.Handler to serve them.
+//
+// A Metrics is safe for concurrent use by multiple goroutines. Its fields
+// must not change once it is in use.
+type Metrics struct {
+	// Namespace prefixes the metric names.
+	// When left as "", "golangsdk" will be used as a default.
+	Namespace string
+
+	// Buckets are the upper bounds of the latency histogram buckets, in
+	// seconds and increasing order.
+	// When left empty, DefaultBuckets will be used.
+	Buckets []float64
+
+	mu         sync.Mutex
+	requests   map[statusKey]uint64
+	operations map[operationKey]*operationMetrics
+}
+
+type operationKey struct {
+	service, operation string
+}
+
+type statusKey struct {
+	operationKey
+	status string
+}
+
+type operationMetrics struct {
+	buckets       []uint64
+	count         uint64
+	sum           float64
+	retries       uint64
+	bytesSent     int64
+	bytesReceived int64
+}
+
+// NewMetrics returns a Metrics with the default namespace and buckets.
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+// StartSpan satisfies golangsdk.Instrumentation. Metrics are only recorded
+// once a call ends.
+func (m *Metrics) StartSpan(ctx context.Context, call *golangsdk.APICall) context.Context {
+	return ctx
+}
+
+// EndSpan records call.
+func (m *Metrics) EndSpan(ctx context.Context, call *golangsdk.APICall) {
+	op := operationKey{service: call.ServiceType, operation: call.Operation}
+	status := "error"
+	if call.StatusCode != 0 {
+		status = strconv.Itoa(call.StatusCode)
+	}
+	buckets := m.buckets()
+	seconds := call.Latency.Seconds()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.requests == nil {
+		m.requests = make(map[statusKey]uint64)
+		m.operations = make(map[operationKey]*operationMetrics)
+	}
+	m.requests[statusKey{op, status}]++
+
+	o := m.operations[op]
+	if o == nil {
+		o = &operationMetrics{buckets: make([]uint64, len(buckets))}
+		m.operations[op] = o
+	}
+	for i, le := range buckets {
+		if seconds <= le {
+			o.buckets[i]++
+		}
+	}
+	o.count++
+	o.sum += seconds
+	o.retries += uint64(call.Retries)
+	o.bytesSent += call.BytesSent
+	o.bytesReceived += call.BytesReceived
+}
+
+// WriteTo writes the metrics to w in the Prometheus text format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	ns := m.Namespace
+	if ns == "" {
+		ns = "golangsdk"
+	}
+	buckets := m.buckets()
+
+	m.mu.Lock()
+	statuses := make([]statusKey, 0, len(m.requests))
+	for k := range m.requests {
+		statuses = append(statuses, k)
+	}
+	ops := make([]operationKey, 0, len(m.operations))
+	for k := range m.operations {
+		ops = append(ops, k)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].operationKey != statuses[j].operationKey {
+			return statuses[i].operationKey.less(statuses[j].operationKey)
+		}
+		return statuses[i].status < statuses[j].status
+	})
+	sort.Slice(ops, func(i, j int) bool {
+		return ops[i].less(ops[j])
+	})
+
+	var b bytes.Buffer
+	header(&b, ns+"_requests_total", "counter", "API calls by service, operation and status.")
+	for _, k := range statuses {
+		fmt.Fprintf(&b, "%s_requests_total{%s,status=\"%s\"} %d\n", ns, k.labels(), k.status, m.requests[k])
+	}
+
+	header(&b, ns+"_request_duration_seconds", "histogram", "Latency of the API calls, including retries.")
+	for _, k := range ops {
+		o := m.operations[k]
+		for i, le := range buckets {
+			fmt.Fprintf(&b, "%s_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n", ns, k.labels(), formatFloat(le), o.buckets[i])
+		}
+		fmt.Fprintf(&b, "%s_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", ns, k.labels(), o.count)
+		fmt.Fprintf(&b, "%s_request_duration_seconds_sum{%s} %s\n", ns, k.labels(), formatFloat(o.sum))
+		fmt.Fprintf(&b, "%s_request_duration_seconds_count{%s} %d\n", ns, k.labels(), o.count)
+	}
+
+	header(&b, ns+"_request_retries_total", "counter", "Attempts of the API calls after the first one.")
+	for _, k := range ops {
+		fmt.Fprintf(&b, "%s_request_retries_total{%s} %d\n", ns, k.labels(), m.operations[k].retries)
+	}
+
+	header(&b, ns+"_request_sent_bytes_total", "counter", "Bytes of the request bodies sent.")
+	for _, k := range ops {
+		fmt.Fprintf(&b, "%s_request_sent_bytes_total{%s} %d\n", ns, k.labels(), m.operations[k].bytesSent)
+	}
+
+	header(&b, ns+"_request_received_bytes_total", "counter", "Bytes of the response bodies received.")
+	for _, k := range ops {
+		fmt.Fprintf(&b, "%s_request_received_bytes_total{%s} %d\n", ns, k.labels(), m.operations[k].bytesReceived)
+	}
+	m.mu.Unlock()
+
+	return b.WriteTo(w)
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+func (m *Metrics) buckets() []float64 {
+	if len(m.Buckets) == 0 {
+		return DefaultBuckets
+	}
+	return m.Buckets
+}
+
+func (k operationKey) less(other operationKey) bool {
+	if k.service != other.service {
+		return k.service < other.service
+	}
+	return k.operation < other.operation
+}
+
+func (k operationKey) labels() string {
+	return fmt.Sprintf("service=\"%s\",operation=\"%s\"", escapeLabel(k.service), escapeLabel(k.operation))
+}
+
+func header(b *bytes.Buffer, name, kind, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabel escapes a label value for the Prometheus text format.
+func escapeLabel(v string) string {
+	return labelEscaper.Replace(v)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,2 @@
+// instrumentation
+package testing
@@ -0,0 +1,91 @@
+package testing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/instrumentation"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+const expectedMetrics = `# HELP sdk_requests_total API calls by service, operation and status.
+# TYPE sdk_requests_total counter
+sdk_requests_total{service="compute",operation="GET /servers/{id}",status="200"} 2
+sdk_requests_total{service="compute",operation="GET /servers/{id}",status="error"} 1
+sdk_requests_total{service="vpc",operation="POST /vpcs \"x\"",status="201"} 1
+# HELP sdk_request_duration_seconds Latency of the API calls, including retries.
+# TYPE sdk_request_duration_seconds histogram
+sdk_request_duration_seconds_bucket{service="compute",operation="GET /servers/{id}",le="0.1"} 1
+sdk_request_duration_seconds_bucket{service="compute",operation="GET /servers/{id}",le="1"} 2
+sdk_request_duration_seconds_bucket{service="compute",operation="GET /servers/{id}",le="+Inf"} 3
+sdk_request_duration_seconds_sum{service="compute",operation="GET /servers/{id}"} 2.55
+sdk_request_duration_seconds_count{service="compute",operation="GET /servers/{id}"} 3
+sdk_request_duration_seconds_bucket{service="vpc",operation="POST /vpcs \"x\"",le="0.1"} 1
+sdk_request_duration_seconds_bucket{service="vpc",operation="POST /vpcs \"x\"",le="1"} 1
+sdk_request_duration_seconds_bucket{service="vpc",operation="POST /vpcs \"x\"",le="+Inf"} 1
+sdk_request_duration_seconds_sum{service="vpc",operation="POST /vpcs \"x\""} 0.01
+sdk_request_duration_seconds_count{service="vpc",operation="POST /vpcs \"x\""} 1
+# HELP sdk_request_retries_total Attempts of the API calls after the first one.
+# TYPE sdk_request_retries_total counter
+sdk_request_retries_total{service="compute",operation="GET /servers/{id}"} 2
+sdk_request_retries_total{service="vpc",operation="POST /vpcs \"x\""} 0
+# HELP sdk_request_sent_bytes_total Bytes of the request bodies sent.
+# TYPE sdk_request_sent_bytes_total counter
+sdk_request_sent_bytes_total{service="compute",operation="GET /servers/{id}"} 0
+sdk_request_sent_bytes_total{service="vpc",operation="POST /vpcs \"x\""} 30
+# HELP sdk_request_received_bytes_total Bytes of the response bodies received.
+# TYPE sdk_request_received_bytes_total counter
+sdk_request_received_bytes_total{service="compute",operation="GET /servers/{id}"} 300
+sdk_request_received_bytes_total{service="vpc",operation="POST /vpcs \"x\""} 50
+`
+
+func TestMetricsWriteTo(t *testing.T) {
+	m := &instrumentation.Metrics{Namespace: "sdk", Buckets: []float64{0.1, 1}}
+	calls := []golangsdk.APICall{
+		{ServiceType: "compute", Operation: "GET /servers/{id}", StatusCode: 200, Latency: 50 * time.Millisecond, BytesReceived: 100},
+		{ServiceType: "compute", Operation: "GET /servers/{id}", StatusCode: 200, Latency: 500 * time.Millisecond, Retries: 2, BytesReceived: 200},
+		{ServiceType: "compute", Operation: "GET /servers/{id}", Latency: 2 * time.Second},
+		{ServiceType: "vpc", Operation: `POST /vpcs "x"`, StatusCode: 201, Latency: 10 * time.Millisecond, BytesSent: 30, BytesReceived: 50},
+	}
+	for i := range calls {
+		ctx := m.StartSpan(context.Background(), &calls[i])
+		m.EndSpan(ctx, &calls[i])
+	}
+
+	var b bytes.Buffer
+	_, err := m.WriteTo(&b)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, expectedMetrics, b.String())
+}
+
+func TestMetricsFromProviderClient(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/servers/42", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{}`)
+	})
+
+	m := instrumentation.NewMetrics()
+	p := new(golangsdk.ProviderClient)
+	p.Instrumentation = m
+	client := &golangsdk.ServiceClient{ProviderClient: p, Endpoint: th.Endpoint(), Type: "compute"}
+
+	_, err := client.Get(client.ServiceURL("servers", "42"), nil, nil)
+	th.AssertNoErr(t, err)
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	th.CheckEquals(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	th.AssertEquals(t, true, bytes.Contains(rec.Body.Bytes(),
+		[]byte(`golangsdk_requests_total{service="compute",operation="GET /servers/{id}",status="200"} 1`)))
+	th.AssertEquals(t, true, bytes.Contains(rec.Body.Bytes(),
+		[]byte(`golangsdk_request_duration_seconds_count{service="compute",operation="GET /servers/{id}"} 1`)))
+}
@@ -0,0 +1,119 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/instrumentation"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+var traceParentPattern = regexp.MustCompile(`^00-([0-9a-f]{32})-([0-9a-f]{16})-([0-9a-f]{2})$`)
+
+func setupTracedClient() (*golangsdk.ServiceClient, *[]string, *[]instrumentation.Span) {
+	var headers []string
+	th.Mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get("traceparent"))
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{}`)
+	})
+
+	var spans []instrumentation.Span
+	p := new(golangsdk.ProviderClient)
+	p.Instrumentation = &instrumentation.Tracer{
+		OnEnd: func(span instrumentation.Span) {
+			spans = append(spans, span)
+		},
+	}
+	return &golangsdk.ServiceClient{ProviderClient: p, Endpoint: th.Endpoint(), Type: "compute"}, &headers, &spans
+}
+
+func TestTracerNewTrace(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	client, headers, spans := setupTracedClient()
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Get(client.ServiceURL("servers"), nil, nil)
+		th.AssertNoErr(t, err)
+	}
+
+	th.AssertEquals(t, 2, len(*headers))
+	th.AssertEquals(t, 2, len(*spans))
+	for i, header := range *headers {
+		m := traceParentPattern.FindStringSubmatch(header)
+		if m == nil {
+			t.Fatalf("unexpected traceparent %q", header)
+		}
+		span := (*spans)[i]
+		th.CheckEquals(t, m[1], span.TraceID)
+		th.CheckEquals(t, m[2], span.SpanID)
+		th.CheckEquals(t, "01", m[3])
+		th.CheckEquals(t, "", span.ParentID)
+		th.CheckEquals(t, true, span.Sampled)
+		th.CheckEquals(t, "GET /servers", span.Name)
+		th.CheckEquals(t, http.StatusOK, span.Call.StatusCode)
+		th.CheckEquals(t, false, span.End.Before(span.Start))
+	}
+
+	// Calls outside of a trace start a trace each.
+	if (*spans)[0].TraceID == (*spans)[1].TraceID {
+		t.Fatalf("expected two traces, got %s twice", (*spans)[0].TraceID)
+	}
+}
+
+func TestTracerContinuesTrace(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	client, headers, spans := setupTracedClient()
+
+	parent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"
+	ctx, err := instrumentation.ContextWithTraceParent(context.Background(), parent)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, parent, instrumentation.TraceParent(ctx))
+
+	_, err = client.GetCtx(ctx, client.ServiceURL("servers"), nil, nil)
+	th.AssertNoErr(t, err)
+
+	m := traceParentPattern.FindStringSubmatch((*headers)[0])
+	if m == nil {
+		t.Fatalf("unexpected traceparent %q", (*headers)[0])
+	}
+	th.CheckEquals(t, "4bf92f3577b34da6a3ce929d0e0e4736", m[1])
+	th.CheckEquals(t, "00", m[3])
+
+	span := (*spans)[0]
+	th.CheckEquals(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.TraceID)
+	th.CheckEquals(t, "00f067aa0ba902b7", span.ParentID)
+	th.CheckEquals(t, m[2], span.SpanID)
+	th.CheckEquals(t, false, span.Sampled)
+}
+
+func TestContextWithTraceParentInvalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473x-00f067aa0ba902b7-01",
+	}
+	for _, traceparent := range invalid {
+		ctx, err := instrumentation.ContextWithTraceParent(context.Background(), traceparent)
+		if _, ok := err.(golangsdk.ErrInvalidInput); !ok {
+			t.Errorf("expected ErrInvalidInput for %q, got %v", traceparent, err)
+		}
+		th.CheckEquals(t, "", instrumentation.TraceParent(ctx))
+	}
+
+	// Later versions may append fields.
+	_, err := instrumentation.ContextWithTraceParent(context.Background(),
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra")
+	th.AssertNoErr(t, err)
+}
@@ -0,0 +1,175 @@
+package instrumentation
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+)
+
+// Span is an API call traced by a Tracer.
+type Span struct {
+	// TraceID and SpanID identify the span as 32 and 16 lowercase
+	// hexadecimal digits. ParentID is the SpanID of the span the call was made
+	// in, or "" if the call started a trace.
+	TraceID  string
+	SpanID   string
+	ParentID string
+
+	// Sampled is the sampled flag propagated with the trace.
+	Sampled bool
+
+	// Name is the operation of the call, such as "GET /v1/{id}/vpcs/{id}".
+	Name string
+
+	// Start and End are the times the call was sent and returned.
+	Start time.Time
+	End   time.Time
+
+	// Call describes the call.
+	Call golangsdk.APICall
+}
+
+// Tracer is a golangsdk.Instrumentation that makes each API call a span of a
+// W3C Trace Context trace, and propagates it to the services with the
+// traceparent header. A call continues the trace of its context, set with
+// ContextWithTraceParent, or starts a new sampled trace.
+type Tracer struct {
+	// OnEnd, if set, receives each span once its call returns, to export it.
+	OnEnd func(Span)
+}
+
+// traceParent is the W3C trace context of a span.
+type traceParent struct {
+	traceID [16]byte
+	spanID  [8]byte
+	flags   byte
+}
+
+func (p traceParent) String() string {
+	return fmt.Sprintf("00-%s-%s-%02x", hex.EncodeToString(p.traceID[:]), hex.EncodeToString(p.spanID[:]), p.flags)
+}
+
+type traceParentKey struct{}
+
+// activeSpan is the span of a call in progress.
+type activeSpan struct {
+	traceParent
+	parent string
+	start  time.Time
+}
+
+type activeSpanKey struct{}
+
+// ContextWithTraceParent returns a copy of ctx in which API calls continue
+// the trace of traceparent, such as the header of an incoming request. It
+// returns an error if traceparent is malformed.
+func ContextWithTraceParent(ctx context.Context, traceparent string) (context.Context, error) {
+	p, err := parseTraceParent(traceparent)
+	if err != nil {
+		return ctx, err
+	}
+	return context.WithValue(ctx, traceParentKey{}, p), nil
+}
+
+// TraceParent returns the traceparent header value of the trace ctx belongs
+// to, or "" if it doesn't belong to one.
+func TraceParent(ctx context.Context) string {
+	if p, ok := ctx.Value(traceParentKey{}).(traceParent); ok {
+		return p.String()
+	}
+	return ""
+}
+
+// StartSpan starts the span of call and adds its traceparent header to the
+// request.
+func (t *Tracer) StartSpan(ctx context.Context, call *golangsdk.APICall) context.Context {
+	span := &activeSpan{start: time.Now()}
+	if parent, ok := ctx.Value(traceParentKey{}).(traceParent); ok {
+		span.traceID = parent.traceID
+		span.flags = parent.flags
+		span.parent = hex.EncodeToString(parent.spanID[:])
+	} else {
+		randomID(span.traceID[:])
+		span.flags = 0x01
+	}
+	randomID(span.spanID[:])
+
+	call.RequestHeader.Set("traceparent", span.String())
+	ctx = context.WithValue(ctx, traceParentKey{}, span.traceParent)
+	return context.WithValue(ctx, activeSpanKey{}, span)
+}
+
+// EndSpan ends the span of call and passes it to OnEnd.
+func (t *Tracer) EndSpan(ctx context.Context, call *golangsdk.APICall) {
+	span, ok := ctx.Value(activeSpanKey{}).(*activeSpan)
+	if !ok || t.OnEnd == nil {
+		return
+	}
+	t.OnEnd(Span{
+		TraceID:  hex.EncodeToString(span.traceID[:]),
+		SpanID:   hex.EncodeToString(span.spanID[:]),
+		ParentID: span.parent,
+		Sampled:  span.flags&0x01 != 0,
+		Name:     call.Operation,
+		Start:    span.start,
+		End:      span.start.Add(call.Latency),
+		Call:     *call,
+	})
+}
+
+// parseTraceParent parses a traceparent header value. Versions after 00 are
+// parsed as version 00, as the specification requires.
+func parseTraceParent(s string) (traceParent, error) {
+	var p traceParent
+	s = strings.TrimSpace(s)
+	parts := strings.Split(s, "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return p, invalidTraceParent(s)
+	}
+	if parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) || strings.ToLower(s) != s {
+		return p, invalidTraceParent(s)
+	}
+
+	var version, flags [1]byte
+	if _, err := hex.Decode(version[:], []byte(parts[0])); err != nil {
+		return p, invalidTraceParent(s)
+	}
+	if _, err := hex.Decode(p.traceID[:], []byte(parts[1])); err != nil {
+		return p, invalidTraceParent(s)
+	}
+	if _, err := hex.Decode(p.spanID[:], []byte(parts[2])); err != nil {
+		return p, invalidTraceParent(s)
+	}
+	if _, err := hex.Decode(flags[:], []byte(parts[3])); err != nil {
+		return p, invalidTraceParent(s)
+	}
+	if p.traceID == [16]byte{} || p.spanID == [8]byte{} {
+		return p, invalidTraceParent(s)
+	}
+	p.flags = flags[0]
+	return p, nil
+}
+
+func invalidTraceParent(s string) error {
+	err := golangsdk.ErrInvalidInput{}
+	err.Argument = "traceparent"
+	err.Value = s
+	return err
+}
+
+// randomID fills id with random bytes, which are never all zero.
+func randomID(id []byte) {
+	for {
+		rand.Read(id)
+		for _, b := range id {
+			if b != 0 {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package golangsdk
+
+import (
+	"context"
+	"net/http"
+)
+
+// RequestHandler performs the API call described by method, url and options.
+type RequestHandler func(ctx context.Context, method, url string, options *RequestOpts) (*http.Response, error)
+
+// Interceptor wraps every API call made through a ProviderClient. It receives
+// the call with its OkCodes already resolved and the next handler of the chain.
+// An interceptor may change the method, URL or options before calling next,
+// inspect or replace the response and error that next returns, or
+// short-circuit the call by returning without calling next at all.
+//
+// Interceptors see each call once: retries and re-authentication happen
+// inside the innermost handler.
+type Interceptor func(ctx context.Context, method, url string, options *RequestOpts, next RequestHandler) (*http.Response, error)
+
+// UseInterceptors appends interceptors to the chain of the ProviderClient.
+// Interceptors run in the order they were added: the first one added is the
+// outermost and sees the call first and its result last.
+func (client *ProviderClient) UseInterceptors(interceptors ...Interceptor) {
+	client.Interceptors = append(client.Interceptors, interceptors...)
+}
+
+// intercept runs the interceptor chain of the client around handler.
+func (client *ProviderClient) intercept(ctx context.Context, method, url string, options *RequestOpts, handler RequestHandler) (*http.Response, error) {
+	for i := len(client.Interceptors) - 1; i >= 0; i-- {
+		interceptor, next := client.Interceptors[i], handler
+		handler = func(ctx context.Context, method, url string, options *RequestOpts) (*http.Response, error) {
+			return interceptor(ctx, method, url, options, next)
+		}
+	}
+	return handler(ctx, method, url, options)
+}
@@ -0,0 +1,265 @@
+package golangsdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Statuses reported by asynchronous jobs.
+const (
+	JobStatusInit    = "INIT"
+	JobStatusRunning = "RUNNING"
+	JobStatusSuccess = "SUCCESS"
+	JobStatusFail    = "FAIL"
+)
+
+const (
+	// DefaultJobMinInterval is the delay before the first job poll when a
+	// JobTracker does not set MinInterval.
+	DefaultJobMinInterval = 1 * time.Second
+
+	// DefaultJobMaxInterval caps the delay between job polls when a
+	// JobTracker does not set MaxInterval.
+	DefaultJobMaxInterval = 10 * time.Second
+)
+
+// Job is the status of an asynchronous job, as returned by the job query API
+// of services such as ECS, EVS, ELB and NAT.
+type Job struct {
+	JobID      string                 `json:"job_id"`
+	JobType    string                 `json:"job_type"`
+	Status     string                 `json:"status"`
+	Entities   map[string]interface{} `json:"entities"`
+	BeginTime  string                 `json:"begin_time"`
+	EndTime    string                 `json:"end_time"`
+	ErrorCode  string                 `json:"error_code"`
+	FailReason string                 `json:"fail_reason"`
+	Message    string                 `json:"message"`
+	Code       string                 `json:"code"`
+
+	// SubJobs holds the sub-jobs reported at the top level of the job. Some
+	// services report them under Entities instead; AllSubJobs covers both.
+	SubJobs []Job `json:"sub_jobs"`
+}
+
+// Done reports whether the job reached a final status.
+func (j *Job) Done() bool {
+	return j.Status == JobStatusSuccess || j.Status == JobStatusFail
+}
+
+// AllSubJobs returns the sub-jobs of the job, whether the service reports them
+// at the top level or under the "sub_jobs" entity.
+func (j *Job) AllSubJobs() ([]Job, error) {
+	if len(j.SubJobs) > 0 {
+		return j.SubJobs, nil
+	}
+	var subJobs []Job
+	if _, ok := j.Entities["sub_jobs"]; !ok {
+		return subJobs, nil
+	}
+	err := j.ExtractEntity("sub_jobs", &subJobs)
+	return subJobs, err
+}
+
+// ExtractEntity unmarshals the entity stored under key, such as "server_id" or
+// "volume_id", into to.
+func (j *Job) ExtractEntity(key string, to interface{}) error {
+	e, ok := j.Entities[key]
+	if !ok {
+		return ErrJobEntityNotFound{JobID: j.JobID, Key: key}
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, to)
+}
+
+// ExtractJob interprets a Result as a Job.
+func (r Result) ExtractJob() (*Job, error) {
+	job := new(Job)
+	err := r.ExtractInto(job)
+	return job, err
+}
+
+// ErrJobFailed is returned when an asynchronous job ends with the FAIL status.
+type ErrJobFailed struct {
+	BaseError
+	Job *Job
+}
+
+func (e ErrJobFailed) Error() string {
+	e.DefaultErrString = fmt.Sprintf("Job %s failed with code %s: %s", e.Job.JobID, e.Job.ErrorCode, e.Job.FailReason)
+	return e.choseErrString()
+}
+
+// ErrJobTimeout is returned when a JobTracker gives up waiting for a job.
+// Job holds the last status observed, if any.
+type ErrJobTimeout struct {
+	ErrTimeOut
+	Job *Job
+}
+
+func (e ErrJobTimeout) Error() string {
+	status := "unknown"
+	if e.Job != nil {
+		status = e.Job.Status
+	}
+	e.DefaultErrString = fmt.Sprintf("A timeout occurred while waiting for the job, last status: %s", status)
+	return e.choseErrString()
+}
+
+// ErrJobEntityNotFound is returned by Job.ExtractEntity when the job has no
+// entity under the requested key.
+type ErrJobEntityNotFound struct {
+	BaseError
+	JobID string
+	Key   string
+}
+
+func (e ErrJobEntityNotFound) Error() string {
+	e.DefaultErrString = fmt.Sprintf("Job %s has no entity %q", e.JobID, e.Key)
+	return e.choseErrString()
+}
+
+// JobTracker polls asynchronous jobs until they finish. The zero value of
+// every field but Client selects a default.
+type JobTracker struct {
+	// Client is used to query the job. A job URI that is not an absolute URL
+	// is resolved against its resource base, see JobURL.
+	Client *ServiceClient
+
+	// MinInterval is the delay before the first poll. The delay doubles after
+	// each poll, up to MaxInterval.
+	MinInterval time.Duration
+
+	// MaxInterval caps the delay between polls.
+	MaxInterval time.Duration
+
+	// Timeout limits how long Wait polls. Zero means no limit other than the
+	// deadline of the context passed to Wait.
+	Timeout time.Duration
+
+	// OnProgress, if set, is called with the job after every poll.
+	OnProgress func(job *Job)
+
+	// JobVersion, if set, replaces the version segment of job URIs, for the
+	// services that report their jobs under a version other than the one their
+	// job API is served under: the ELB v1.0 services report "/v1/..." URIs.
+	JobVersion string
+}
+
+// NewJobTracker returns a JobTracker that queries jobs through client with the
+// default polling intervals.
+func NewJobTracker(client *ServiceClient) *JobTracker {
+	return &JobTracker{Client: client}
+}
+
+// JobURL resolves a job URI, such as "/v1/{project_id}/jobs/{job_id}", against
+// the scheme and host of the client's resource base, and the part of its path
+// before the version segment. The path of the URI is kept as is unless
+// JobVersion is set. Absolute URLs are returned unchanged.
+func (t *JobTracker) JobURL(uri string) (string, error) {
+	if strings.HasPrefix(uri, "http://") || strings.HasPrefix(uri, "https://") {
+		return uri, nil
+	}
+	base, err := url.Parse(t.Client.ResourceBaseURL())
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(uri, "/") {
+		uri = "/" + uri
+	}
+
+	var prefix string
+	segments := strings.Split(base.Path, "/")
+	for i, segment := range segments {
+		if versionSegment.MatchString(segment) {
+			prefix = strings.Join(segments[:i], "/")
+			break
+		}
+	}
+
+	if t.JobVersion != "" {
+		parts := strings.SplitN(uri, "/", 3)
+		if len(parts) == 3 && versionSegment.MatchString(parts[1]) {
+			uri = "/" + t.JobVersion + "/" + parts[2]
+		}
+	}
+	return base.Scheme + "://" + base.Host + prefix + uri, nil
+}
+
+// versionSegment matches an API version path segment, such as "v1" or "v1.0".
+var versionSegment = regexp.MustCompile(`^v[0-9]+(\.[0-9]+)*$`)
+
+// Get queries the current status of the job at uri.
+func (t *JobTracker) Get(ctx context.Context, uri string) (*Job, error) {
+	jobURL, err := t.JobURL(uri)
+	if err != nil {
+		return nil, err
+	}
+	job := new(Job)
+	_, err = t.Client.GetCtx(ctx, jobURL, job, nil)
+	if err != nil {
+		return nil, err
+	}
+	t.Client.LogDebug("job status", map[string]interface{}{"job_id": job.JobID, "status": job.Status})
+	return job, nil
+}
+
+// Wait polls the job at uri until it succeeds, fails, the tracker's Timeout
+// elapses or ctx is done. It returns the final job on success, ErrJobFailed
+// if the job failed and ErrJobTimeout if the Timeout elapsed.
+func (t *JobTracker) Wait(ctx context.Context, uri string) (*Job, error) {
+	min, max := t.MinInterval, t.MaxInterval
+	if min <= 0 {
+		min = DefaultJobMinInterval
+	}
+	if max <= 0 {
+		max = DefaultJobMaxInterval
+	}
+
+	w := Waiter{
+		Timeout:     t.Timeout,
+		Delay:       min,
+		MinInterval: min,
+		MaxInterval: max,
+		NoJitter:    true,
+	}
+	state, err := w.Wait(ctx, func(ctx context.Context) (bool, interface{}, error) {
+		job, err := t.Get(ctx, uri)
+		if err != nil {
+			return false, nil, err
+		}
+
+		if t.OnProgress != nil {
+			t.OnProgress(job)
+		}
+
+		if job.Status == JobStatusFail {
+			return false, job, ErrJobFailed{Job: job}
+		}
+		return job.Status == JobStatusSuccess, job, nil
+	})
+
+	job, _ := state.(*Job)
+	if e, ok := err.(ErrTimeOut); ok {
+		return job, ErrJobTimeout{Job: job, ErrTimeOut: e}
+	}
+	return job, err
+}
+
+// WaitForEntity waits for the job at uri to succeed, then extracts the entity
+// stored under key into to.
+func (t *JobTracker) WaitForEntity(ctx context.Context, uri, key string, to interface{}) error {
+	job, err := t.Wait(ctx, uri)
+	if err != nil {
+		return err
+	}
+	return job.ExtractEntity(key, to)
+}
@@ -0,0 +1,186 @@
+package golangsdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Redacted replaces the value of every secret that is logged.
+const Redacted = "***"
+
+// Logger receives the debug output of a ProviderClient. Each entry is a short
+// message plus a set of fields, such as "method", "url", "status",
+// "duration" and "request_id". Secrets are redacted before the fields are
+// handed to the Logger.
+type Logger interface {
+	Debug(msg string, fields map[string]interface{})
+}
+
+// NewStdLogger returns a Logger that writes entries to l as
+// "[DEBUG] msg key=value ..." lines with the fields sorted by key. If l is nil,
+// entries are written to standard error with the flags of the standard logger
+// of the log package.
+func NewStdLogger(l *log.Logger) Logger {
+	if l == nil {
+		l = log.New(os.Stderr, "", log.LstdFlags)
+	}
+	return stdLogger{l}
+}
+
+type stdLogger struct {
+	logger *log.Logger
+}
+
+func (l stdLogger) Debug(msg string, fields map[string]interface{}) {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("[DEBUG] ")
+	b.WriteString(msg)
+	for _, k := range keys {
+		fmt.Fprintf(&b, " %s=%v", k, fields[k])
+	}
+	l.logger.Print(b.String())
+}
+
+// LogDebug sends a debug entry to the Logger of the client, if one is set.
+// Service packages use it instead of writing to the log package directly.
+func (client *ProviderClient) LogDebug(msg string, fields map[string]interface{}) {
+	if client == nil || client.Logger == nil {
+		return
+	}
+	client.Logger.Debug(msg, fields)
+}
+
+// sensitiveHeaders are the headers whose values are never logged.
+var sensitiveHeaders = map[string]bool{
+	"X-Auth-Token":     true,
+	"X-Subject-Token":  true,
+	"X-Security-Token": true,
+	"Authorization":    true,
+}
+
+// sensitiveKeys are the lower-cased JSON keys whose values are never logged.
+// "identity" and "passwordcredentials" cover the whole credential part of the
+// Identity v3 and v2 token requests.
+var sensitiveKeys = map[string]bool{
+	"password":            true,
+	"passwordcredentials": true,
+	"identity":            true,
+	"token":               true,
+	"secret":              true,
+	"access":              true,
+	"access_key":          true,
+	"accesskey":           true,
+	"secret_key":          true,
+	"secretkey":           true,
+	"ak":                  true,
+	"sk":                  true,
+	"securitytoken":       true,
+	"security_token":      true,
+	"adminpass":           true,
+	"admin_pass":          true,
+	"db_user_pwd":         true,
+}
+
+// RedactHeaders returns a copy of h suitable for logging, with the values of
+// authentication headers replaced by Redacted.
+func RedactHeaders(h http.Header) map[string]string {
+	redacted := make(map[string]string, len(h))
+	for k, v := range h {
+		if sensitiveHeaders[http.CanonicalHeaderKey(k)] {
+			redacted[k] = Redacted
+			continue
+		}
+		redacted[k] = strings.Join(v, ",")
+	}
+	return redacted
+}
+
+// RedactBody returns the JSON encoding of body suitable for logging, with the
+// values of passwords, tokens and keys replaced by Redacted.
+func RedactBody(body interface{}) string {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Sprintf("<unserializable body: %s>", err)
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return fmt.Sprintf("<unserializable body: %s>", err)
+	}
+
+	b, err = json.Marshal(redactValue(v))
+	if err != nil {
+		return fmt.Sprintf("<unserializable body: %s>", err)
+	}
+	return string(b)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if sensitiveKeys[strings.ToLower(k)] {
+				t[k] = Redacted
+			} else {
+				t[k] = redactValue(val)
+			}
+		}
+	case []interface{}:
+		for i, val := range t {
+			t[i] = redactValue(val)
+		}
+	}
+	return v
+}
+
+// logRequest logs a completed HTTP exchange. resp may be nil if the request
+// failed before a response was received.
+func (client *ProviderClient) logRequest(req *http.Request, options *RequestOpts, resp *http.Response, err error, start time.Time) {
+	if client.Logger == nil {
+		return
+	}
+
+	fields := map[string]interface{}{
+		"method":          req.Method,
+		"url":             req.URL.String(),
+		"duration":        time.Since(start),
+		"request_headers": RedactHeaders(req.Header),
+	}
+	if options.JSONBody != nil {
+		fields["request_body"] = RedactBody(options.JSONBody)
+	}
+	if resp != nil {
+		fields["status"] = resp.StatusCode
+		fields["response_headers"] = RedactHeaders(resp.Header)
+		if id := requestID(resp.Header); id != "" {
+			fields["request_id"] = id
+		}
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+
+	client.Logger.Debug("HTTP request", fields)
+}
+
+// requestID returns the request ID the cloud assigned to a response.
+func requestID(h http.Header) string {
+	for _, k := range requestIDHeaders {
+		if v := h.Get(k); v != "" {
+			return v
+		}
+	}
+	return ""
+}
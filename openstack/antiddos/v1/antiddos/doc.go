@@ -85,7 +85,12 @@ Example to query the defense statuses of all EIPs.
         Ip:     "49.",
     }
 
-    actual, err := antiddos.ListStatus(client.ServiceClient(), listOpt).Extract()
+    allPages, err := antiddos.ListStatus(client.ServiceClient(), listOpt).AllPages()
+    if err != nil {
+      panic(err)
+    }
+
+    statuses, err := antiddos.ExtractStatuses(allPages)
     if err != nil {
       panic(err)
     }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/pagination"
 )
 
 type CreateOpts struct {
@@ -43,42 +44,42 @@ func Create(client *golangsdk.ServiceClient, floatingIpId string, opts CreateOpt
 		return
 	}
 
-	_, r.Err = client.Post(CreateURL(client, floatingIpId), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(CreateURL(client, floatingIpId), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
 func DailyReport(client *golangsdk.ServiceClient, floatingIpId string) (r DailyReportResult) {
 	url := DailyReportURL(client, floatingIpId)
-	_, r.Err = client.Get(url, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(url, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
 func Delete(client *golangsdk.ServiceClient, floatingIpId string) (r DeleteResult) {
 	url := DeleteURL(client, floatingIpId)
-	_, r.Err = client.Delete(url, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Delete(url, &golangsdk.RequestOpts{
 		JSONResponse: &r.Body,
 		OkCodes:      []int{200},
-	})
+	}))
 	return
 }
 
 func Get(client *golangsdk.ServiceClient, floatingIpId string) (r GetResult) {
 	url := GetURL(client, floatingIpId)
-	_, r.Err = client.Get(url, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(url, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
 func GetStatus(client *golangsdk.ServiceClient, floatingIpId string) (r GetStatusResult) {
 	url := GetStatusURL(client, floatingIpId)
-	_, r.Err = client.Get(url, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(url, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
@@ -107,17 +108,17 @@ func GetTask(client *golangsdk.ServiceClient, opts GetTaskOptsBuilder) (r GetTas
 		url += query
 	}
 
-	_, r.Err = client.Get(url, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(url, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
 func ListConfigs(client *golangsdk.ServiceClient) (r ListConfigsResult) {
 	url := ListConfigsURL(client)
-	_, r.Err = client.Get(url, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(url, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
@@ -152,9 +153,9 @@ func ListLogs(client *golangsdk.ServiceClient, floatingIpId string, opts ListLog
 		url += query
 	}
 
-	_, r.Err = client.Get(url, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(url, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
@@ -181,21 +182,19 @@ func (opts ListStatusOpts) ToListStatusQuery() (string, error) {
 	return q.String(), err
 }
 
-func ListStatus(client *golangsdk.ServiceClient, opts ListStatusOptsBuilder) (r ListStatusResult) {
+func ListStatus(client *golangsdk.ServiceClient, opts ListStatusOptsBuilder) pagination.Pager {
 	url := ListStatusURL(client)
 	if opts != nil {
 		query, err := opts.ToListStatusQuery()
 		if err != nil {
-			r.Err = err
-			return
+			return pagination.Pager{Err: err}
 		}
 		url += query
 	}
 
-	_, r.Err = client.Get(url, &r.Body, &golangsdk.RequestOpts{
-		OkCodes: []int{200},
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return StatusPage{pagination.OffsetPageBase{PageResult: r}}
 	})
-	return
 }
 
 type UpdateOpts struct {
@@ -234,9 +233,9 @@ func Update(client *golangsdk.ServiceClient, floatingIpId string, opts UpdateOpt
 		return
 	}
 
-	_, r.Err = client.Put(UpdateURL(client, floatingIpId), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Put(UpdateURL(client, floatingIpId), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
@@ -265,8 +264,8 @@ func WeeklyReport(client *golangsdk.ServiceClient, opts WeeklyReportOptsBuilder)
 		url += query
 	}
 
-	_, r.Err = client.Get(url, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(url, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/pagination"
 )
 
 type commonResult struct {
@@ -230,34 +231,38 @@ type ListLogsResponse struct {
 	} `json:"logs,"`
 }
 
-type ListStatusResult struct {
-	commonResult
+// StatusPage is a single page of defense statuses, paginated by offset and limit.
+type StatusPage struct {
+	pagination.OffsetPageBase
 }
 
-func (r ListStatusResult) Extract() (*ListStatusResponse, error) {
-	var response ListStatusResponse
-	err := r.ExtractInto(&response)
-	return &response, err
+// IsEmpty returns true if a StatusPage contains no defense statuses.
+func (r StatusPage) IsEmpty() (bool, error) {
+	s, err := ExtractStatuses(r)
+	return len(s) == 0, err
 }
 
-type ListStatusResponse struct {
-	// Total number of EIPs
-	Total int `json:"total,"`
+// ExtractStatuses extracts the defense statuses from a page of ListStatus.
+func ExtractStatuses(r pagination.Page) ([]DdosStatus, error) {
+	var s struct {
+		DdosStatus []DdosStatus `json:"ddosStatus"`
+	}
+	err := (r.(StatusPage)).ExtractInto(&s)
+	return s.DdosStatus, err
+}
 
-	// List of defense statuses
-	DdosStatus []struct {
-		// Floating IP address
-		FloatingIpAddress string `json:"floating_ip_address,"`
+type DdosStatus struct {
+	// Floating IP address
+	FloatingIpAddress string `json:"floating_ip_address,"`
 
-		// ID of an EIP
-		FloatingIpId string `json:"floating_ip_id,"`
+	// ID of an EIP
+	FloatingIpId string `json:"floating_ip_id,"`
 
-		// EIP type.
-		NetworkType string `json:"network_type,"`
+	// EIP type.
+	NetworkType string `json:"network_type,"`
 
-		// Defense status
-		Status string `json:"status,"`
-	} `json:"ddosStatus,"`
+	// Defense status
+	Status string `json:"status,"`
 }
 
 type UpdateResult struct {
@@ -133,8 +133,13 @@ func HandleUpdateSuccessfully(t *testing.T) {
 	})
 }
 
-var ListStatusResponse = antiddos.ListStatusResponse{
-	Total: 2,
+var ListStatusResponse = []antiddos.DdosStatus{
+	{
+		FloatingIpId:      "4d60bba4-0791-4e82-8262-9bdffaeb1d14",
+		FloatingIpAddress: "49.4.4.36",
+		NetworkType:       "EIP",
+		Status:            "notConfig",
+	},
 }
 
 const ListStatusOutput string = `
@@ -166,19 +171,7 @@ func HandleListStatusSuccessfully(t *testing.T) {
 		}
 	})
 
-	ListStatusResponse.DdosStatus = append(ListStatusResponse.DdosStatus, struct {
-		FloatingIpAddress string `json:"floating_ip_address,"`
-		FloatingIpId      string `json:"floating_ip_id,"`
-		NetworkType       string `json:"network_type,"`
-		Status            string `json:"status,"`
-	}{
-		FloatingIpId:      "4d60bba4-0791-4e82-8262-9bdffaeb1d14",
-		FloatingIpAddress: "49.4.4.36",
-		NetworkType:       "EIP",
-		Status:            "notConfig",
-	})
 }
-
 var ListConfigsResponse = antiddos.ListConfigsResponse{
 	TrafficLimitedList: []struct {
 		TrafficPosId     int `json:"traffic_pos_id,"`
@@ -80,9 +80,11 @@ func TestListStatus(t *testing.T) {
 		Ip:     "49.",
 	}
 
-	actual, err := antiddos.ListStatus(client.ServiceClient(), listOpt).Extract()
+	allPages, err := antiddos.ListStatus(client.ServiceClient(), listOpt).AllPages()
 	th.AssertNoErr(t, err)
-	th.CheckDeepEquals(t, &ListStatusResponse, actual)
+	actual, err := antiddos.ExtractStatuses(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ListStatusResponse, actual)
 }
 
 func TestListConfigs(t *testing.T) {
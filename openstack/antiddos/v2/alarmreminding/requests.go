@@ -6,8 +6,8 @@ import (
 
 func WarnAlert(client *golangsdk.ServiceClient) (r WarnAlertResult) {
 	url := WarnAlertURL(client)
-	_, r.Err = client.Get(url, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(url, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
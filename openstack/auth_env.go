@@ -23,6 +23,13 @@ OS_TENANT_ID and OS_TENANT_NAME are mutually exclusive to OS_PROJECT_ID and
 OS_PROJECT_NAME. If OS_PROJECT_ID and OS_PROJECT_NAME are set, they will
 still be referred as "tenant" in Gophercloud.
 
+OS_PASSCODE holds the TOTP passcode of an account protected by multi-factor
+authentication. When it is set, OS_PASSWORD is optional.
+
+OS_APPLICATION_CREDENTIAL_ID, or OS_APPLICATION_CREDENTIAL_NAME together with
+OS_USERNAME or OS_USERID, authenticate with an application credential whose
+secret is OS_APPLICATION_CREDENTIAL_SECRET. OS_PASSWORD is not needed then.
+
 To use this function, first set the OS_* environment variables (for example,
 by sourcing an `openrc` file), then:
 
@@ -38,6 +45,10 @@ func AuthOptionsFromEnv() (golangsdk.AuthOptions, error) {
 	tenantName := os.Getenv("OS_TENANT_NAME")
 	domainID := os.Getenv("OS_DOMAIN_ID")
 	domainName := os.Getenv("OS_DOMAIN_NAME")
+	passcode := os.Getenv("OS_PASSCODE")
+	applicationCredentialID := os.Getenv("OS_APPLICATION_CREDENTIAL_ID")
+	applicationCredentialName := os.Getenv("OS_APPLICATION_CREDENTIAL_NAME")
+	applicationCredentialSecret := os.Getenv("OS_APPLICATION_CREDENTIAL_SECRET")
 
 	// If OS_PROJECT_ID is set, overwrite tenantID with the value.
 	if v := os.Getenv("OS_PROJECT_ID"); v != "" {
@@ -54,14 +65,26 @@ func AuthOptionsFromEnv() (golangsdk.AuthOptions, error) {
 		return nilOptions, err
 	}
 
-	if username == "" && userID == "" {
-		err := golangsdk.ErrMissingInput{Argument: "username"}
-		return nilOptions, err
-	}
-
-	if password == "" {
-		err := golangsdk.ErrMissingInput{Argument: "password"}
-		return nilOptions, err
+	if applicationCredentialID != "" || applicationCredentialName != "" {
+		if applicationCredentialID == "" && username == "" && userID == "" {
+			err := golangsdk.ErrMissingInput{Argument: "username"}
+			return nilOptions, err
+		}
+
+		if applicationCredentialSecret == "" {
+			err := golangsdk.ErrMissingInput{Argument: "applicationCredentialSecret"}
+			return nilOptions, err
+		}
+	} else {
+		if username == "" && userID == "" {
+			err := golangsdk.ErrMissingInput{Argument: "username"}
+			return nilOptions, err
+		}
+
+		if password == "" && passcode == "" {
+			err := golangsdk.ErrMissingInput{Argument: "password"}
+			return nilOptions, err
+		}
 	}
 
 	ao := golangsdk.AuthOptions{
@@ -73,6 +96,60 @@ func AuthOptionsFromEnv() (golangsdk.AuthOptions, error) {
 		TenantName:       tenantName,
 		DomainID:         domainID,
 		DomainName:       domainName,
+		Passcode:         passcode,
+
+		ApplicationCredentialID:     applicationCredentialID,
+		ApplicationCredentialName:   applicationCredentialName,
+		ApplicationCredentialSecret: applicationCredentialSecret,
+	}
+
+	return ao, nil
+}
+
+/*
+AKSKAuthOptionsFromEnv fills out a golangsdk.AKSKAuthOptions structure with the
+settings found on the OS_* environment variables.
+
+The following variables provide sources of truth: OS_AUTH_URL, OS_ACCESS_KEY,
+OS_SECRET_KEY, OS_PROJECT_ID, OS_DOMAIN_ID and OS_SECURITY_TOKEN.
+
+OS_AUTH_URL, OS_ACCESS_KEY and OS_SECRET_KEY must have settings, as must one
+of OS_PROJECT_ID and OS_DOMAIN_ID, or an error will result.
+
+	opts, err := openstack.AKSKAuthOptionsFromEnv()
+	provider, err := openstack.AuthenticatedClient(opts)
+*/
+func AKSKAuthOptionsFromEnv() (golangsdk.AKSKAuthOptions, error) {
+	authURL := os.Getenv("OS_AUTH_URL")
+	accessKey := os.Getenv("OS_ACCESS_KEY")
+	secretKey := os.Getenv("OS_SECRET_KEY")
+	projectID := os.Getenv("OS_PROJECT_ID")
+	domainID := os.Getenv("OS_DOMAIN_ID")
+	securityToken := os.Getenv("OS_SECURITY_TOKEN")
+
+	if authURL == "" {
+		return golangsdk.AKSKAuthOptions{}, golangsdk.ErrMissingInput{Argument: "authURL"}
+	}
+
+	if accessKey == "" {
+		return golangsdk.AKSKAuthOptions{}, golangsdk.ErrMissingInput{Argument: "accessKey"}
+	}
+
+	if secretKey == "" {
+		return golangsdk.AKSKAuthOptions{}, golangsdk.ErrMissingInput{Argument: "secretKey"}
+	}
+
+	if projectID == "" && domainID == "" {
+		return golangsdk.AKSKAuthOptions{}, golangsdk.ErrMissingInput{Argument: "projectID"}
+	}
+
+	ao := golangsdk.AKSKAuthOptions{
+		IdentityEndpoint: authURL,
+		ProjectId:        projectID,
+		DomainID:         domainID,
+		AccessKey:        accessKey,
+		SecretKey:        secretKey,
+		SecurityToken:    securityToken,
 	}
 
 	return ao, nil
@@ -2,7 +2,6 @@ package configurations
 
 import (
 	"encoding/base64"
-	"log"
 
 	"github.com/huaweicloud/golangsdk"
 	"github.com/huaweicloud/golangsdk/pagination"
@@ -22,17 +21,13 @@ func (opts CreateOpts) ToConfigurationCreateMap() (map[string]interface{}, error
 	if err != nil {
 		return nil, err
 	}
-	log.Printf("[DEBUG] ToConfigurationCreateMap b is: %#v", b)
-	log.Printf("[DEBUG] ToConfigurationCreateMap opts is: %#v", opts)
 	publicIp := opts.InstanceConfig.PubicIp
-	log.Printf("[DEBUG] ToConfigurationCreateMap publicIp is: %#v", publicIp)
 
 	if publicIp != (PublicIpOpts{}) {
 		public_ip := map[string]interface{}{}
 		eip := map[string]interface{}{}
 		bandwidth := map[string]interface{}{}
 		eip_raw := publicIp.Eip
-		log.Printf("[DEBUG] ToConfigurationCreateMap eip_raw is: %#v", eip_raw)
 		if eip_raw != (EipOpts{}) {
 			if eip_raw.IpType != "" {
 				eip["ip_type"] = eip_raw.IpType
@@ -63,7 +58,6 @@ func (opts CreateOpts) ToConfigurationCreateMap() (map[string]interface{}, error
 		}
 		b["instance_config"].(map[string]interface{})["user_data"] = &userData
 	}
-	log.Printf("[DEBUG] ToConfigurationCreateMap b is: %#v", b)
 	return b, nil
 }
 
@@ -117,22 +111,22 @@ func Create(client *golangsdk.ServiceClient, opts CreateOptsBuilder) (r CreateRe
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(createURL(client), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(createURL(client), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
 //Get is a method by which can be able to access to get a configuration of
 //autoscaling detailed information
 func Get(client *golangsdk.ServiceClient, id string) (r GetResult) {
-	_, r.Err = client.Get(getURL(client, id), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(getURL(client, id), &r.Body, nil))
 	return
 }
 
 //Delete
 func Delete(client *golangsdk.ServiceClient, id string) (r DeleteResult) {
-	_, r.Err = client.Delete(deleteURL(client, id), nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Delete(deleteURL(client, id), nil))
 	return
 }
 
@@ -49,21 +49,21 @@ func Create(client *golangsdk.ServiceClient, opts CreateOptsBuilder) (r CreateRe
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(createURL(client), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(createURL(client), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
 //DeleteGroup is a method of deleting a group by group id
 func Delete(client *golangsdk.ServiceClient, id string) (r DeleteResult) {
-	_, r.Err = client.Delete(deleteURL(client, id), nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Delete(deleteURL(client, id), nil))
 	return
 }
 
 //GetGroup is a method of getting the detailed information of the group by id
 func Get(client *golangsdk.ServiceClient, id string) (r GetResult) {
-	_, r.Err = client.Get(getURL(client, id), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(getURL(client, id), &r.Body, nil))
 	return
 }
 
@@ -75,6 +75,8 @@ type ListOpts struct {
 	Name            string `q:"scaling_group_name"`
 	ConfigurationID string `q:"scaling_configuration_id"`
 	Status          string `q:"scaling_group_status"`
+	StartNumber     int    `q:"start_number"`
+	Limit           int    `q:"limit"`
 }
 
 // ToGroupListQuery formats a ListOpts into a query string.
@@ -94,7 +96,11 @@ func List(client *golangsdk.ServiceClient, ops ListOptsBuilder) pagination.Pager
 	}
 
 	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
-		return GroupPage{pagination.SinglePageBase(r)}
+		return GroupPage{pagination.OffsetPageBase{
+			PageResult:  r,
+			OffsetParam: "start_number",
+			TotalKey:    "total_number",
+		}}
 	})
 }
 
@@ -135,9 +141,9 @@ func Update(client *golangsdk.ServiceClient, id string, opts UpdateOptsBuilder)
 		return
 	}
 
-	_, r.Err = client.Put(updateURL(client, id), body, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Put(updateURL(client, id), body, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
@@ -159,9 +165,9 @@ func doAction(client *golangsdk.ServiceClient, id string, opts ActionOptsBuilder
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(enableURL(client, id), &b, nil, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(enableURL(client, id), &b, nil, &golangsdk.RequestOpts{
 		OkCodes: []int{204},
-	})
+	}))
 	return
 }
 
@@ -74,7 +74,7 @@ type SecurityGroup struct {
 }
 
 type GroupPage struct {
-	pagination.SinglePageBase
+	pagination.OffsetPageBase
 }
 
 // IsEmpty returns true if a ListResult contains no Volumes.
@@ -1,17 +1,13 @@
 package groups
 
 import (
-	"log"
-
 	"github.com/huaweicloud/golangsdk"
 )
 
 const resourcePath = "scaling_group"
 
 func createURL(c *golangsdk.ServiceClient) string {
-	ur := c.ServiceURL(c.ProjectID, resourcePath)
-	log.Printf("[DEBUG] Create URL is: %#v", ur)
-	return ur
+	return c.ServiceURL(c.ProjectID, resourcePath)
 }
 
 func deleteURL(c *golangsdk.ServiceClient, id string) string {
@@ -63,7 +63,7 @@ func Delete(client *golangsdk.ServiceClient, id string, opts DeleteOptsBuilder)
 		}
 		url += q
 	}
-	_, r.Err = client.Delete(url, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Delete(url, nil))
 	return
 }
 
@@ -90,9 +90,9 @@ func batch(client *golangsdk.ServiceClient, groupID string, opts BatchOptsBuilde
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(batchURL(client, groupID), b, nil, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(batchURL(client, groupID), b, nil, &golangsdk.RequestOpts{
 		OkCodes: []int{204},
-	})
+	}))
 	return
 }
 
@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"reflect"
@@ -9,8 +10,11 @@ import (
 
 	"github.com/huaweicloud/golangsdk"
 	tokens2 "github.com/huaweicloud/golangsdk/openstack/identity/v2/tokens"
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/catalog"
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/extensions/federation"
 	tokens3 "github.com/huaweicloud/golangsdk/openstack/identity/v3/tokens"
 	"github.com/huaweicloud/golangsdk/openstack/utils"
+	"github.com/huaweicloud/golangsdk/pagination"
 )
 
 const (
@@ -84,8 +88,8 @@ Example:
 		Region: os.Getenv("OS_REGION_NAME"),
 	})
 */
-func AuthenticatedClient(options golangsdk.AuthOptions) (*golangsdk.ProviderClient, error) {
-	client, err := NewClient(options.IdentityEndpoint)
+func AuthenticatedClient(options golangsdk.AuthOptionsProvider) (*golangsdk.ProviderClient, error) {
+	client, err := NewClient(options.GetIdentityEndpoint())
 	if err != nil {
 		return nil, err
 	}
@@ -98,8 +102,20 @@ func AuthenticatedClient(options golangsdk.AuthOptions) (*golangsdk.ProviderClie
 }
 
 // Authenticate or re-authenticate against the most recent identity service
-// supported at the provided endpoint.
-func Authenticate(client *golangsdk.ProviderClient, options golangsdk.AuthOptions) error {
+// supported at the provided endpoint. AKSKAuthOptions never request a token:
+// they only configure request signing and look up the service catalog.
+func Authenticate(client *golangsdk.ProviderClient, options golangsdk.AuthOptionsProvider) error {
+	switch opts := options.(type) {
+	case golangsdk.AKSKAuthOptions:
+		return v3AKSKAuth(client, "", opts, golangsdk.EndpointOpts{})
+	case golangsdk.AuthOptions:
+		return authenticate(client, opts)
+	default:
+		return fmt.Errorf("Unrecognized auth options type: %T", options)
+	}
+}
+
+func authenticate(client *golangsdk.ProviderClient, options golangsdk.AuthOptions) error {
 	versions := []*utils.Version{
 		{ID: v2, Priority: 20, Suffix: "/v2.0/"},
 		{ID: v3, Priority: 30, Suffix: "/v3/"},
@@ -112,9 +128,9 @@ func Authenticate(client *golangsdk.ProviderClient, options golangsdk.AuthOption
 
 	switch chosen.ID {
 	case v2:
-		return v2auth(client, endpoint, options, golangsdk.EndpointOpts{})
+		return v2auth(context.Background(), client, endpoint, options, golangsdk.EndpointOpts{})
 	case v3:
-		return v3auth(client, endpoint, &options, golangsdk.EndpointOpts{})
+		return v3auth(context.Background(), client, endpoint, &options, golangsdk.EndpointOpts{})
 	default:
 		// The switch statement must be out of date from the versions list.
 		return fmt.Errorf("Unrecognized identity version: %s", chosen.ID)
@@ -123,10 +139,10 @@ func Authenticate(client *golangsdk.ProviderClient, options golangsdk.AuthOption
 
 // AuthenticateV2 explicitly authenticates against the identity v2 endpoint.
 func AuthenticateV2(client *golangsdk.ProviderClient, options golangsdk.AuthOptions, eo golangsdk.EndpointOpts) error {
-	return v2auth(client, "", options, eo)
+	return v2auth(context.Background(), client, "", options, eo)
 }
 
-func v2auth(client *golangsdk.ProviderClient, endpoint string, options golangsdk.AuthOptions, eo golangsdk.EndpointOpts) error {
+func v2auth(ctx context.Context, client *golangsdk.ProviderClient, endpoint string, options golangsdk.AuthOptions, eo golangsdk.EndpointOpts) error {
 	v2Client, err := NewIdentityV2(client, eo)
 	if err != nil {
 		return err
@@ -146,7 +162,7 @@ func v2auth(client *golangsdk.ProviderClient, endpoint string, options golangsdk
 		TokenID:          options.TokenID,
 	}
 
-	result := tokens2.Create(v2Client, v2Opts)
+	result := tokens2.CreateWithContext(ctx, v2Client, v2Opts)
 
 	token, err := result.ExtractToken()
 	if err != nil {
@@ -161,10 +177,15 @@ func v2auth(client *golangsdk.ProviderClient, endpoint string, options golangsdk
 	if options.AllowReauth {
 		client.ReauthFunc = func() error {
 			client.TokenID = ""
-			return v2auth(client, endpoint, options, eo)
+			return v2auth(context.Background(), client, endpoint, options, eo)
+		}
+		client.ReauthContextFunc = func(ctx context.Context) error {
+			client.TokenID = ""
+			return v2auth(ctx, client, endpoint, options, eo)
 		}
 	}
 	client.TokenID = token.ID
+	client.TokenExpiresAt = token.ExpiresAt
 	client.ProjectID = token.Tenant.ID
 	client.EndpointLocator = func(opts golangsdk.EndpointOpts) (string, error) {
 		return V2EndpointURL(catalog, opts)
@@ -175,10 +196,10 @@ func v2auth(client *golangsdk.ProviderClient, endpoint string, options golangsdk
 
 // AuthenticateV3 explicitly authenticates against the identity v3 service.
 func AuthenticateV3(client *golangsdk.ProviderClient, options tokens3.AuthOptionsBuilder, eo golangsdk.EndpointOpts) error {
-	return v3auth(client, "", options, eo)
+	return v3auth(context.Background(), client, "", options, eo)
 }
 
-func v3auth(client *golangsdk.ProviderClient, endpoint string, opts tokens3.AuthOptionsBuilder, eo golangsdk.EndpointOpts) error {
+func v3auth(ctx context.Context, client *golangsdk.ProviderClient, endpoint string, opts tokens3.AuthOptionsBuilder, eo golangsdk.EndpointOpts) error {
 	// Override the generated service endpoint with the one returned by the version endpoint.
 	v3Client, err := NewIdentityV3(client, eo)
 	if err != nil {
@@ -189,34 +210,161 @@ func v3auth(client *golangsdk.ProviderClient, endpoint string, opts tokens3.Auth
 		v3Client.Endpoint = endpoint
 	}
 
-	result := tokens3.Create(v3Client, opts)
+	key := tokenCacheKey(v3Client.Endpoint, opts, client.TokenCacheSecret)
+	result, cached := cachedToken(client, key)
+	if !cached {
+		result = tokens3.CreateWithContext(ctx, v3Client, opts)
+	}
 
-	token, err := result.ExtractToken()
+	token, err := useV3Token(client, result)
 	if err != nil {
 		return err
 	}
 
+	if !cached {
+		cacheToken(client, key, result, token)
+	}
+
+	if opts.CanReauth() {
+		client.ReauthFunc = func() error {
+			evictCachedToken(client, key, client.TokenID)
+			client.TokenID = ""
+			return v3auth(context.Background(), client, endpoint, opts, eo)
+		}
+		client.ReauthContextFunc = func(ctx context.Context) error {
+			evictCachedToken(client, key, client.TokenID)
+			client.TokenID = ""
+			return v3auth(ctx, client, endpoint, opts, eo)
+		}
+	}
+
+	return nil
+}
+
+// useV3Token makes client use the token, project and service catalog of
+// the result of an identity v3 token request.
+func useV3Token(client *golangsdk.ProviderClient, result tokens3.CreateResult) (*tokens3.Token, error) {
+	token, err := result.ExtractToken()
+	if err != nil {
+		return nil, err
+	}
+
 	project, err := result.ExtractProject()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	catalog, err := result.ExtractServiceCatalog()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	client.TokenID = token.ID
-	client.ProjectID = project.ID
+	client.TokenExpiresAt = token.ExpiresAt
+	client.ProjectID = ""
+	if project != nil {
+		client.ProjectID = project.ID
+	}
+	client.EndpointLocator = func(opts golangsdk.EndpointOpts) (string, error) {
+		return V3EndpointURL(catalog, opts)
+	}
 
-	if opts.CanReauth() {
+	return token, nil
+}
+
+// AuthenticateFederated authenticates with the credentials issued by a
+// federated identity provider. They are exchanged for an unscoped token,
+// which is then rescoped to the scope of opts.
+func AuthenticateFederated(client *golangsdk.ProviderClient, opts federation.AuthOptions, eo golangsdk.EndpointOpts) error {
+	return federatedAuth(context.Background(), client, "", opts, eo)
+}
+
+func federatedAuth(ctx context.Context, client *golangsdk.ProviderClient, endpoint string, opts federation.AuthOptions, eo golangsdk.EndpointOpts) error {
+	v3Client, err := NewIdentityV3(client, eo)
+	if err != nil {
+		return err
+	}
+
+	if endpoint != "" {
+		v3Client.Endpoint = endpoint
+	}
+
+	result := federation.CreateWithContext(ctx, v3Client, opts)
+	if opts.Scope != (tokens3.Scope{}) {
+		unscoped, err := result.ExtractToken()
+		if err != nil {
+			return err
+		}
+		result = tokens3.CreateWithContext(ctx, v3Client, &tokens3.AuthOptions{
+			TokenID: unscoped.ID,
+			Scope:   opts.Scope,
+		})
+	}
+
+	if _, err := useV3Token(client, result); err != nil {
+		return err
+	}
+
+	if opts.AllowReauth {
 		client.ReauthFunc = func() error {
 			client.TokenID = ""
-			return v3auth(client, endpoint, opts, eo)
+			return federatedAuth(context.Background(), client, endpoint, opts, eo)
+		}
+		client.ReauthContextFunc = func(ctx context.Context) error {
+			client.TokenID = ""
+			return federatedAuth(ctx, client, endpoint, opts, eo)
+		}
+	}
+
+	return nil
+}
+
+// AuthenticateAKSK explicitly configures AK/SK request signing and fetches
+// the service catalog from the identity v3 service.
+func AuthenticateAKSK(client *golangsdk.ProviderClient, options golangsdk.AKSKAuthOptions, eo golangsdk.EndpointOpts) error {
+	return v3AKSKAuth(client, "", options, eo)
+}
+
+func v3AKSKAuth(client *golangsdk.ProviderClient, endpoint string, options golangsdk.AKSKAuthOptions, eo golangsdk.EndpointOpts) error {
+	if client.Credentials == nil {
+		if options.AccessKey == "" {
+			return golangsdk.ErrMissingInput{Argument: "AccessKey"}
+		}
+		if options.SecretKey == "" {
+			return golangsdk.ErrMissingInput{Argument: "SecretKey"}
+		}
+	}
+	if options.ProjectId == "" && options.DomainID == "" {
+		return golangsdk.ErrMissingInput{Argument: "ProjectId"}
+	}
+
+	v3Client, err := NewIdentityV3(client, eo)
+	if err != nil {
+		return err
+	}
+
+	if endpoint != "" {
+		v3Client.Endpoint = endpoint
+	}
+
+	client.AKSKAuthOptions = options
+	client.ProjectID = options.ProjectId
+
+	var entries []tokens3.CatalogEntry
+	err = catalog.List(v3Client).EachPage(func(page pagination.Page) (bool, error) {
+		catalogList, err := catalog.ExtractServiceCatalog(page)
+		if err != nil {
+			return false, err
 		}
+		entries = append(entries, catalogList...)
+		return true, nil
+	})
+	if err != nil {
+		return err
 	}
+
 	client.EndpointLocator = func(opts golangsdk.EndpointOpts) (string, error) {
-		return V3EndpointURL(catalog, opts)
+		return V3EndpointURL(&tokens3.ServiceCatalog{Entries: entries}, opts)
 	}
 
 	return nil
@@ -230,7 +378,7 @@ func NewIdentityV2(client *golangsdk.ProviderClient, eo golangsdk.EndpointOpts)
 	var err error
 	if !reflect.DeepEqual(eo, golangsdk.EndpointOpts{}) {
 		eo.ApplyDefaults(clientType)
-		endpoint, err = client.EndpointLocator(eo)
+		endpoint, err = resolveEndpoint(client, eo, eo.Type, eo.Type, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -251,7 +399,7 @@ func NewIdentityV3(client *golangsdk.ProviderClient, eo golangsdk.EndpointOpts)
 	var err error
 	if !reflect.DeepEqual(eo, golangsdk.EndpointOpts{}) {
 		eo.ApplyDefaults(clientType)
-		endpoint, err = client.EndpointLocator(eo)
+		endpoint, err = resolveEndpoint(client, eo, eo.Type, eo.Type, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -271,10 +419,13 @@ func NewIdentityV3(client *golangsdk.ProviderClient, eo golangsdk.EndpointOpts)
 	}, nil
 }
 
+// initClientOpts resolves the endpoint of the service of type clientType:
+// from the overrides of eo, then the service catalog, then the template of
+// eo.
 func initClientOpts(client *golangsdk.ProviderClient, eo golangsdk.EndpointOpts, clientType string) (*golangsdk.ServiceClient, error) {
 	sc := new(golangsdk.ServiceClient)
 	eo.ApplyDefaults(clientType)
-	url, err := client.EndpointLocator(eo)
+	url, err := resolveEndpoint(client, eo, eo.Type, eo.Type, nil)
 	if err != nil {
 		return sc, err
 	}
@@ -284,6 +435,22 @@ func initClientOpts(client *golangsdk.ProviderClient, eo golangsdk.EndpointOpts,
 	return sc, nil
 }
 
+// initDerivedClientOpts is like initClientOpts for a service of type
+// serviceType that is missing from the service catalog. Unless eo overrides
+// it, its endpoint is derived by derive from the endpoint of catalogType.
+func initDerivedClientOpts(client *golangsdk.ProviderClient, eo golangsdk.EndpointOpts, serviceType, catalogType string, derive func(string) string) (*golangsdk.ServiceClient, error) {
+	sc := new(golangsdk.ServiceClient)
+	eo.ApplyDefaults(catalogType)
+	url, err := resolveEndpoint(client, eo, serviceType, eo.Type, derive)
+	if err != nil {
+		return sc, err
+	}
+	sc.ProviderClient = client
+	sc.Endpoint = url
+	sc.Type = serviceType
+	return sc, nil
+}
+
 // NewObjectStorageV1 creates a ServiceClient that may be used with the v1
 // object storage package.
 func NewObjectStorageV1(client *golangsdk.ProviderClient, eo golangsdk.EndpointOpts) (*golangsdk.ServiceClient, error) {
@@ -369,33 +536,29 @@ func NewLoadBalancerV2(client *golangsdk.ProviderClient, eo golangsdk.EndpointOp
 
 // NewOtcV1 creates a ServiceClient that may be used with the v1 network package.
 func NewElbV1(client *golangsdk.ProviderClient, eo golangsdk.EndpointOpts, otctype string) (*golangsdk.ServiceClient, error) {
-	sc, err := initClientOpts(client, eo, "compute")
-	//fmt.Printf("client=%+v.\n", sc)
-	sc.Endpoint = strings.Replace(strings.Replace(sc.Endpoint, "ecs", otctype, 1), "/v2/", "/v1.0/", 1)
-	//fmt.Printf("url=%s.\n", sc.Endpoint)
+	sc, err := initDerivedClientOpts(client, eo, otctype, "compute", func(url string) string {
+		return strings.Replace(strings.Replace(url, "ecs", otctype, 1), "/v2/", "/v1.0/", 1)
+	})
 	sc.ResourceBase = sc.Endpoint
-	sc.Type = otctype
 	return sc, err
 }
 
 // NewSmnServiceV2 creates a ServiceClient that may be used to access the v2 Simple Message Notification service.
 func NewSmnServiceV2(client *golangsdk.ProviderClient, eo golangsdk.EndpointOpts) (*golangsdk.ServiceClient, error) {
-
-	sc, err := initClientOpts(client, eo, "compute")
-	sc.Endpoint = strings.Replace(sc.Endpoint, "ecs", "smn", 1)
+	sc, err := initDerivedClientOpts(client, eo, "smn", "compute", func(url string) string {
+		return strings.Replace(url, "ecs", "smn", 1)
+	})
 	sc.ResourceBase = sc.Endpoint + "notifications/"
-	sc.Type = "smn"
 	return sc, err
 }
 
 //NewRdsServiceV1 creates the a ServiceClient that may be used to access the v1
 //rds service which is a service of db instances management.
 func NewRdsServiceV1(client *golangsdk.ProviderClient, eo golangsdk.EndpointOpts) (*golangsdk.ServiceClient, error) {
-	newsc, err := initClientOpts(client, eo, "compute")
-	rdsendpoint := strings.Replace(strings.Replace(newsc.Endpoint, "ecs", "rds", 1), "/v2/", "/rds/v1/", 1)
-	newsc.Endpoint = rdsendpoint
-	newsc.ResourceBase = rdsendpoint
-	newsc.Type = "rds"
+	newsc, err := initDerivedClientOpts(client, eo, "rds", "compute", func(url string) string {
+		return strings.Replace(strings.Replace(url, "ecs", "rds", 1), "/v2/", "/rds/v1/", 1)
+	})
+	newsc.ResourceBase = newsc.Endpoint
 	return newsc, err
 }
 
@@ -415,10 +578,10 @@ func NewDRSServiceV2(client *golangsdk.ProviderClient, eo golangsdk.EndpointOpts
 }
 
 func NewComputeV1(client *golangsdk.ProviderClient, eo golangsdk.EndpointOpts) (*golangsdk.ServiceClient, error) {
-	sc, err := initClientOpts(client, eo, "compute")
-	e := strings.Replace(sc.Endpoint, "v2", "v1", 1)
-	sc.Endpoint = e
-	sc.ResourceBase = e
+	sc, err := initDerivedClientOpts(client, eo, "compute", "compute", func(url string) string {
+		return strings.Replace(url, "v2", "v1", 1)
+	})
+	sc.ResourceBase = sc.Endpoint
 	return sc, err
 }
 
@@ -432,24 +595,24 @@ func NewAutoScalingService(client *golangsdk.ProviderClient, eo golangsdk.Endpoi
 // NewKmsKeyV1 creates a ServiceClient that may be used to access the v1
 // kms key service.
 func NewKmsKeyV1(client *golangsdk.ProviderClient, eo golangsdk.EndpointOpts) (*golangsdk.ServiceClient, error) {
-	sc, err := initClientOpts(client, eo, "compute")
-	sc.Endpoint = strings.Replace(sc.Endpoint, "ecs", "kms", 1)
-	sc.Endpoint = sc.Endpoint[:strings.LastIndex(sc.Endpoint, "v2")+3]
-	sc.Endpoint = strings.Replace(sc.Endpoint, "v2", "v1.0", 1)
+	sc, err := initDerivedClientOpts(client, eo, "kms", "compute", func(url string) string {
+		url = strings.Replace(url, "ecs", "kms", 1)
+		url = url[:strings.LastIndex(url, "v2")+3]
+		return strings.Replace(url, "v2", "v1.0", 1)
+	})
 	sc.ResourceBase = sc.Endpoint
-	sc.Type = "kms"
 	return sc, err
 }
 
 func NewElasticLoadBalancer(client *golangsdk.ProviderClient, eo golangsdk.EndpointOpts) (*golangsdk.ServiceClient, error) {
-	//sc, err := initClientOpts1(client, eo, "elb")
-	sc, err := initClientOpts(client, eo, "compute")
+	sc, err := initDerivedClientOpts(client, eo, "elb", "compute", func(url string) string {
+		url = strings.Replace(url, "ecs", "elb", 1)
+		url = url[:strings.LastIndex(url, "v2")+3]
+		return strings.Replace(url, "v2", "v1.0", 1)
+	})
 	if err != nil {
 		return sc, err
 	}
-	sc.Endpoint = strings.Replace(sc.Endpoint, "ecs", "elb", 1)
-	sc.Endpoint = sc.Endpoint[:strings.LastIndex(sc.Endpoint, "v2")+3]
-	sc.Endpoint = strings.Replace(sc.Endpoint, "v2", "v1.0", 1)
 	sc.ResourceBase = sc.Endpoint
 	return sc, err
 }
@@ -464,9 +627,10 @@ func NewNetworkV1(client *golangsdk.ProviderClient, eo golangsdk.EndpointOpts) (
 
 // NewNatV2 creates a ServiceClient that may be used with the v2 nat package.
 func NewNatV2(client *golangsdk.ProviderClient, eo golangsdk.EndpointOpts) (*golangsdk.ServiceClient, error) {
-	sc, err := initClientOpts(client, eo, "network")
-	sc.Endpoint = strings.Replace(sc.Endpoint, "vpc", "nat", 1)
-	sc.Endpoint = strings.Replace(sc.Endpoint, "myhwclouds", "myhuaweicloud", 1)
+	sc, err := initDerivedClientOpts(client, eo, "nat", "network", func(url string) string {
+		url = strings.Replace(url, "vpc", "nat", 1)
+		return strings.Replace(url, "myhwclouds", "myhuaweicloud", 1)
+	})
 	sc.ResourceBase = sc.Endpoint + "v2.0/"
 	return sc, err
 }
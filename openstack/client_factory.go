@@ -0,0 +1,234 @@
+package openstack
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	tokens3 "github.com/huaweicloud/golangsdk/openstack/identity/v3/tokens"
+)
+
+/*
+ClientFactory issues service clients for any project and region from a single
+credential. It authenticates the credential once, scoped to its domain, then
+rescopes that token to each project on first use. The ProviderClient of each
+project is cached and re-authenticates by rescoping again, so the credential
+itself is only used to renew the domain token.
+
+A ClientFactory is safe for concurrent use by multiple goroutines.
+
+Example:
+
+	provider, err := openstack.NewClient("https://iam.example.com/v3")
+	factory, err := openstack.NewClientFactory(provider, &tokens.AuthOptions{
+		Username:   "{username}",
+		Password:   "{password}",
+		DomainName: "{domain_name}",
+		Scope:      tokens.Scope{DomainName: "{domain_name}"},
+	})
+
+	client, err := factory.ServiceClient("{project_id}", "cn-north-1", "network")
+*/
+type ClientFactory struct {
+	// EndpointOpts are the options used by ServiceClient to resolve
+	// endpoints, such as Availability and Overrides. Its Region and Type are
+	// ignored. It must not be modified once the factory is in use.
+	EndpointOpts golangsdk.EndpointOpts
+
+	base *golangsdk.ProviderClient
+	opts tokens3.AuthOptionsBuilder
+
+	tokenMu   sync.Mutex
+	tokenID   string
+	expiresAt time.Time
+
+	mu       sync.Mutex
+	projects map[string]*factoryProject
+}
+
+// factoryProject is the ProviderClient of a project. done is closed once the
+// first authentication of client has completed, with err as its outcome.
+type factoryProject struct {
+	done   chan struct{}
+	client *golangsdk.ProviderClient
+	err    error
+}
+
+// NewClientFactory returns a ClientFactory authenticating with opts, which
+// should be scoped to a domain. base is an unauthenticated ProviderClient,
+// such as one returned by NewClient: it sends the token requests, and the
+// ProviderClient of each project copies its identity endpoint and HTTP
+// settings. The domain token is issued before NewClientFactory returns.
+func NewClientFactory(base *golangsdk.ProviderClient, opts tokens3.AuthOptionsBuilder) (*ClientFactory, error) {
+	f := &ClientFactory{
+		base:     base,
+		opts:     opts,
+		projects: make(map[string]*factoryProject),
+	}
+	if _, _, err := f.domainToken(context.Background(), ""); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// ProviderClient returns the ProviderClient of the project projectID,
+// authenticating it on first use.
+func (f *ClientFactory) ProviderClient(projectID string) (*golangsdk.ProviderClient, error) {
+	return f.ProviderClientWithContext(context.Background(), projectID)
+}
+
+// ProviderClientWithContext is like ProviderClient, but binds the first
+// authentication of the project to ctx. Concurrent callers for the same
+// project wait for that authentication rather than issuing their own.
+func (f *ClientFactory) ProviderClientWithContext(ctx context.Context, projectID string) (*golangsdk.ProviderClient, error) {
+	for {
+		f.mu.Lock()
+		p, ok := f.projects[projectID]
+		if !ok {
+			p = &factoryProject{done: make(chan struct{})}
+			f.projects[projectID] = p
+			f.mu.Unlock()
+
+			p.client, p.err = f.newProjectClient(ctx, projectID)
+			if p.err != nil {
+				// Forget the failure so that the next caller tries again.
+				f.mu.Lock()
+				delete(f.projects, projectID)
+				f.mu.Unlock()
+			}
+			close(p.done)
+			return p.client, p.err
+		}
+		f.mu.Unlock()
+
+		select {
+		case <-p.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		// The context of the caller that authenticated may have ended
+		// before ours: try again rather than return its error.
+		if errors.Is(p.err, context.Canceled) || errors.Is(p.err, context.DeadlineExceeded) {
+			continue
+		}
+		return p.client, p.err
+	}
+}
+
+// ServiceClient returns a client of the service of type serviceType in
+// region for the project projectID. Its endpoint is resolved from the
+// EndpointOpts of f, then the service catalog of the project. Use
+// ProviderClient with a constructor such as NewNetworkV1 for services whose
+// resources live under a versioned path.
+func (f *ClientFactory) ServiceClient(projectID, region, serviceType string) (*golangsdk.ServiceClient, error) {
+	client, err := f.ProviderClient(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	eo := f.EndpointOpts
+	eo.Region = region
+	eo.Type = ""
+	return initClientOpts(client, eo, serviceType)
+}
+
+func (f *ClientFactory) newProjectClient(ctx context.Context, projectID string) (*golangsdk.ProviderClient, error) {
+	client := &golangsdk.ProviderClient{
+		IdentityBase:       f.base.IdentityBase,
+		IdentityEndpoint:   f.base.IdentityEndpoint,
+		TokenRefreshWindow: f.base.TokenRefreshWindow,
+		HTTPClient:         f.base.HTTPClient,
+		DisableKeepAlives:  f.base.DisableKeepAlives,
+		UserAgent:          f.base.UserAgent,
+		RetryPolicy:        f.base.RetryPolicy,
+		Interceptors:       f.base.Interceptors,
+		RateLimiter:        f.base.RateLimiter,
+		Instrumentation:    f.base.Instrumentation,
+		Logger:             f.base.Logger,
+	}
+	client.UseTokenLock()
+
+	if err := f.authenticateProject(ctx, client, projectID); err != nil {
+		return nil, err
+	}
+
+	client.ReauthFunc = func() error {
+		client.TokenID = ""
+		return f.authenticateProject(context.Background(), client, projectID)
+	}
+	client.ReauthContextFunc = func(ctx context.Context) error {
+		client.TokenID = ""
+		return f.authenticateProject(ctx, client, projectID)
+	}
+
+	return client, nil
+}
+
+// authenticateProject rescopes the domain token to projectID and makes
+// client use the resulting token.
+func (f *ClientFactory) authenticateProject(ctx context.Context, client *golangsdk.ProviderClient, projectID string) error {
+	identity, err := NewIdentityV3(f.base, golangsdk.EndpointOpts{})
+	if err != nil {
+		return err
+	}
+
+	rescope := func(tokenID string) tokens3.CreateResult {
+		return tokens3.CreateWithContext(ctx, identity, &tokens3.AuthOptions{
+			TokenID: tokenID,
+			Scope:   tokens3.Scope{ProjectID: projectID},
+		})
+	}
+
+	tokenID, fresh, err := f.domainToken(ctx, "")
+	if err != nil {
+		return err
+	}
+	result := rescope(tokenID)
+	if result.Err != nil && !fresh {
+		// The cached domain token may have been revoked: issue a new one
+		// and try again.
+		tokenID, _, err = f.domainToken(ctx, tokenID)
+		if err != nil {
+			return err
+		}
+		result = rescope(tokenID)
+	}
+
+	_, err = useV3Token(client, result)
+	return err
+}
+
+// domainToken returns the domain token, and whether it was issued by this
+// call. A new token is issued when there is none yet, when it expires within
+// the refresh window of the base client, or when it is stale.
+func (f *ClientFactory) domainToken(ctx context.Context, stale string) (string, bool, error) {
+	f.tokenMu.Lock()
+	defer f.tokenMu.Unlock()
+
+	window := f.base.TokenRefreshWindow
+	if window == 0 {
+		window = golangsdk.DefaultTokenRefreshWindow
+	}
+	if window < 0 {
+		window = 0
+	}
+	if f.tokenID != "" && f.tokenID != stale && time.Until(f.expiresAt) > window {
+		return f.tokenID, false, nil
+	}
+
+	identity, err := NewIdentityV3(f.base, golangsdk.EndpointOpts{})
+	if err != nil {
+		return "", false, err
+	}
+	token, err := tokens3.CreateWithContext(ctx, identity, f.opts).ExtractToken()
+	if err != nil {
+		return "", false, err
+	}
+
+	f.tokenID = token.ID
+	f.expiresAt = token.ExpiresAt
+	return f.tokenID, true, nil
+}
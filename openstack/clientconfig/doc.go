@@ -0,0 +1,46 @@
+/*
+Package clientconfig configures clients from the clouds.yaml file used by
+OpenStack tools, along with its companion secure.yaml file which usually
+holds the passwords and secret keys left out of clouds.yaml.
+
+Both files are looked up in the current directory, then ~/.config/openstack,
+then /etc/openstack. The OS_CLIENT_CONFIG_FILE and OS_CLIENT_SECURE_FILE
+environment variables point to other locations.
+
+An example clouds.yaml:
+
+	clouds:
+	  mycloud:
+	    auth:
+	      auth_url: https://iam.example.com/v3
+	      username: me
+	      user_domain_name: mydomain
+	      project_name: region-project
+	    region_name: region
+	    interface: public
+	    endpoint_override:
+	      network: https://vpc.region.example.com/
+	    cacert: /etc/ssl/certs/ca.pem
+
+Example to Create an Authenticated Client
+
+	opts := &clientconfig.ClientOpts{
+		Cloud: "mycloud",
+	}
+
+	provider, err := clientconfig.AuthenticatedClient(opts)
+	if err != nil {
+		panic(err)
+	}
+
+	eo, err := clientconfig.EndpointOpts(opts)
+	if err != nil {
+		panic(err)
+	}
+
+	networkClient, err := openstack.NewNetworkV1(provider, eo)
+	if err != nil {
+		panic(err)
+	}
+*/
+package clientconfig
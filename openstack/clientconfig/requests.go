@@ -0,0 +1,345 @@
+package clientconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ClientOpts represents options to customize the way a client is
+// configured.
+type ClientOpts struct {
+	// Cloud is the cloud entry in clouds.yaml to use. It defaults to the
+	// OS_CLOUD environment variable.
+	Cloud string
+}
+
+// LoadCloudsYAML reads the clouds.yaml file and merges the secure.yaml file
+// into it, if there is one. Each file is looked up in the current directory,
+// then ~/.config/openstack, then /etc/openstack, unless the
+// OS_CLIENT_CONFIG_FILE or OS_CLIENT_SECURE_FILE environment variable points
+// to it.
+func LoadCloudsYAML() (map[string]Cloud, error) {
+	clouds, err := findAndReadYAML("clouds.yaml", "OS_CLIENT_CONFIG_FILE")
+	if err != nil {
+		return nil, err
+	}
+	if clouds == nil {
+		return nil, fmt.Errorf("unable to find clouds.yaml")
+	}
+
+	secure, err := findAndReadYAML("secure.yaml", "OS_CLIENT_SECURE_FILE")
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := yaml.Marshal(mergeYAML(clouds, secure))
+	if err != nil {
+		return nil, err
+	}
+
+	var c Clouds
+	if err := yaml.Unmarshal(content, &c); err != nil {
+		return nil, err
+	}
+	return c.Clouds, nil
+}
+
+// GetCloudFromYAML returns the cloud entry named by opts.
+func GetCloudFromYAML(opts *ClientOpts) (*Cloud, error) {
+	name := ""
+	if opts != nil {
+		name = opts.Cloud
+	}
+	if name == "" {
+		name = os.Getenv("OS_CLOUD")
+	}
+	if name == "" {
+		return nil, golangsdk.ErrMissingInput{Argument: "Cloud"}
+	}
+
+	clouds, err := LoadCloudsYAML()
+	if err != nil {
+		return nil, err
+	}
+
+	cloud, ok := clouds[name]
+	if !ok {
+		return nil, fmt.Errorf("cloud %s does not exist in clouds.yaml", name)
+	}
+	if cloud.AuthInfo == nil {
+		cloud.AuthInfo = new(AuthInfo)
+	}
+	return &cloud, nil
+}
+
+// AuthOptions builds the options to authenticate with the "password" or
+// "token" auth type of the cloud entry named by opts.
+func AuthOptions(opts *ClientOpts) (*golangsdk.AuthOptions, error) {
+	cloud, err := GetCloudFromYAML(opts)
+	if err != nil {
+		return nil, err
+	}
+	return authOptions(cloud)
+}
+
+func authOptions(cloud *Cloud) (*golangsdk.AuthOptions, error) {
+	auth := cloud.AuthInfo
+	if auth.AuthURL == "" {
+		return nil, golangsdk.ErrMissingInput{Argument: "auth_url"}
+	}
+
+	ao := &golangsdk.AuthOptions{
+		IdentityEndpoint: auth.AuthURL,
+		TenantID:         auth.ProjectID,
+		TenantName:       auth.ProjectName,
+		DomainID:         firstNonEmpty(auth.UserDomainID, auth.DomainID),
+		DomainName:       firstNonEmpty(auth.UserDomainName, auth.DomainName),
+	}
+
+	switch cloud.AuthType {
+	case "", "password":
+		if auth.Username == "" && auth.UserID == "" {
+			return nil, golangsdk.ErrMissingInput{Argument: "username"}
+		}
+		if auth.Password == "" {
+			return nil, golangsdk.ErrMissingInput{Argument: "password"}
+		}
+		ao.Username = auth.Username
+		ao.UserID = auth.UserID
+		ao.Password = auth.Password
+		ao.AllowReauth = true
+	case "token":
+		if auth.Token == "" {
+			return nil, golangsdk.ErrMissingInput{Argument: "token"}
+		}
+		ao.TokenID = auth.Token
+	default:
+		return nil, fmt.Errorf("auth_type %s does not use AuthOptions", cloud.AuthType)
+	}
+
+	return ao, nil
+}
+
+// AKSKAuthOptions builds the options to authenticate with the "aksk" auth
+// type of the cloud entry named by opts.
+func AKSKAuthOptions(opts *ClientOpts) (*golangsdk.AKSKAuthOptions, error) {
+	cloud, err := GetCloudFromYAML(opts)
+	if err != nil {
+		return nil, err
+	}
+	return akskAuthOptions(cloud)
+}
+
+func akskAuthOptions(cloud *Cloud) (*golangsdk.AKSKAuthOptions, error) {
+	auth := cloud.AuthInfo
+	if cloud.AuthType != "aksk" {
+		return nil, fmt.Errorf("auth_type %s does not use AKSKAuthOptions", cloud.AuthType)
+	}
+	if auth.AuthURL == "" {
+		return nil, golangsdk.ErrMissingInput{Argument: "auth_url"}
+	}
+	if auth.AccessKey == "" {
+		return nil, golangsdk.ErrMissingInput{Argument: "access_key"}
+	}
+	if auth.SecretKey == "" {
+		return nil, golangsdk.ErrMissingInput{Argument: "secret_key"}
+	}
+
+	return &golangsdk.AKSKAuthOptions{
+		IdentityEndpoint: auth.AuthURL,
+		ProjectId:        auth.ProjectID,
+		DomainID:         firstNonEmpty(auth.UserDomainID, auth.DomainID),
+		AccessKey:        auth.AccessKey,
+		SecretKey:        auth.SecretKey,
+		SecurityToken:    auth.SecurityToken,
+	}, nil
+}
+
+// EndpointOpts builds the options to look up service endpoints of the cloud
+// entry named by opts: its region, interface and endpoint overrides.
+func EndpointOpts(opts *ClientOpts) (golangsdk.EndpointOpts, error) {
+	cloud, err := GetCloudFromYAML(opts)
+	if err != nil {
+		return golangsdk.EndpointOpts{}, err
+	}
+	return endpointOpts(cloud), nil
+}
+
+func endpointOpts(cloud *Cloud) golangsdk.EndpointOpts {
+	eo := golangsdk.EndpointOpts{
+		Region:    firstNonEmpty(cloud.RegionName, os.Getenv("OS_REGION_NAME")),
+		Overrides: cloud.EndpointOverride,
+	}
+
+	switch firstNonEmpty(cloud.Interface, cloud.EndpointType) {
+	case "internal", "internalURL":
+		eo.Availability = golangsdk.AvailabilityInternal
+	case "admin", "adminURL":
+		eo.Availability = golangsdk.AvailabilityAdmin
+	case "public", "publicURL":
+		eo.Availability = golangsdk.AvailabilityPublic
+	}
+
+	return eo
+}
+
+// HTTPClient builds an HTTP client with the TLS and proxy settings of the
+// cloud entry named by opts.
+func HTTPClient(opts *ClientOpts) (http.Client, error) {
+	cloud, err := GetCloudFromYAML(opts)
+	if err != nil {
+		return http.Client{}, err
+	}
+	return httpClient(cloud)
+}
+
+func httpClient(cloud *Cloud) (http.Client, error) {
+	tlsConfig := &tls.Config{}
+
+	if cloud.Verify != nil && !*cloud.Verify {
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	if cloud.CACertFile != "" {
+		pem, err := ioutil.ReadFile(cloud.CACertFile)
+		if err != nil {
+			return http.Client{}, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return http.Client{}, fmt.Errorf("no certificates found in %s", cloud.CACertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cloud.ClientCertFile != "" || cloud.ClientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cloud.ClientCertFile, cloud.ClientKeyFile)
+		if err != nil {
+			return http.Client{}, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if cloud.Proxy != "" {
+		u, err := url.Parse(cloud.Proxy)
+		if err != nil {
+			return http.Client{}, err
+		}
+		proxy = http.ProxyURL(u)
+	}
+
+	return http.Client{
+		Transport: &http.Transport{
+			Proxy:               proxy,
+			TLSClientConfig:     tlsConfig,
+			TLSHandshakeTimeout: 10 * time.Second,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}, nil
+}
+
+// AuthenticatedClient returns a ProviderClient authenticated with the cloud
+// entry named by opts, using its TLS and proxy settings. Service clients
+// built from it use the endpoint overrides of the entry instead of the
+// service catalog.
+//
+// Example:
+//
+//	provider, err := clientconfig.AuthenticatedClient(&clientconfig.ClientOpts{
+//		Cloud: "mycloud",
+//	})
+//	eo, err := clientconfig.EndpointOpts(&clientconfig.ClientOpts{
+//		Cloud: "mycloud",
+//	})
+//	client, err := openstack.NewNetworkV1(provider, eo)
+func AuthenticatedClient(opts *ClientOpts) (*golangsdk.ProviderClient, error) {
+	cloud, err := GetCloudFromYAML(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var ao golangsdk.AuthOptionsProvider
+	if cloud.AuthType == "aksk" {
+		akskOpts, err := akskAuthOptions(cloud)
+		if err != nil {
+			return nil, err
+		}
+		ao = *akskOpts
+	} else {
+		pwOpts, err := authOptions(cloud)
+		if err != nil {
+			return nil, err
+		}
+		ao = *pwOpts
+	}
+
+	hc, err := httpClient(cloud)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := openstack.NewClient(ao.GetIdentityEndpoint())
+	if err != nil {
+		return nil, err
+	}
+	client.HTTPClient = hc
+
+	if err := openstack.Authenticate(client, ao); err != nil {
+		return nil, err
+	}
+	overrideEndpoints(client, cloud.EndpointOverride)
+
+	return client, nil
+}
+
+// overrideEndpoints makes the EndpointLocator of client return the URLs in
+// overrides for the service types they hold. Re-authentication replaces the
+// locator, so the reauth functions are wrapped to override it again.
+func overrideEndpoints(client *golangsdk.ProviderClient, overrides map[string]string) {
+	if len(overrides) == 0 {
+		return
+	}
+
+	wrap := func() {
+		locator := client.EndpointLocator
+		client.EndpointLocator = func(eo golangsdk.EndpointOpts) (string, error) {
+			if u, ok := overrides[eo.Type]; ok {
+				return golangsdk.NormalizeURL(u), nil
+			}
+			return locator(eo)
+		}
+	}
+	wrap()
+
+	if reauth := client.ReauthFunc; reauth != nil {
+		client.ReauthFunc = func() error {
+			if err := reauth(); err != nil {
+				return err
+			}
+			wrap()
+			return nil
+		}
+	}
+	if reauth := client.ReauthContextFunc; reauth != nil {
+		client.ReauthContextFunc = func(ctx context.Context) error {
+			if err := reauth(ctx); err != nil {
+				return err
+			}
+			wrap()
+			return nil
+		}
+	}
+}
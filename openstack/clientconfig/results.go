@@ -0,0 +1,69 @@
+package clientconfig
+
+// Clouds represents a collection of Cloud entries in a clouds.yaml file.
+type Clouds struct {
+	Clouds map[string]Cloud `yaml:"clouds" json:"clouds"`
+}
+
+// Cloud represents an entry in a clouds.yaml or secure.yaml file.
+type Cloud struct {
+	// AuthType is the authentication method: "password" (the default),
+	// "token" or "aksk".
+	AuthType string    `yaml:"auth_type" json:"auth_type"`
+	AuthInfo *AuthInfo `yaml:"auth" json:"auth"`
+
+	RegionName string `yaml:"region_name" json:"region_name"`
+
+	// Interface is the availability of the endpoints to use: "public",
+	// "internal" or "admin". EndpointType is an older name for it.
+	Interface    string `yaml:"interface" json:"interface"`
+	EndpointType string `yaml:"endpoint_type" json:"endpoint_type"`
+
+	// EndpointOverride maps service types to the endpoint to use for them
+	// instead of the one in the service catalog.
+	EndpointOverride map[string]string `yaml:"endpoint_override" json:"endpoint_override"`
+
+	// Verify is whether to verify the TLS certificate of the server. It
+	// defaults to true.
+	Verify *bool `yaml:"verify" json:"verify"`
+
+	// CACertFile is a path to a PEM bundle of CA certificates to trust.
+	CACertFile string `yaml:"cacert" json:"cacert"`
+
+	// ClientCertFile and ClientKeyFile are paths to a PEM client
+	// certificate and its key, for mutual TLS.
+	ClientCertFile string `yaml:"cert" json:"cert"`
+	ClientKeyFile  string `yaml:"key" json:"key"`
+
+	// Proxy is the URL of the HTTP proxy to send requests through. The
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used
+	// when it is empty.
+	Proxy string `yaml:"proxy" json:"proxy"`
+}
+
+// AuthInfo represents the auth section of a cloud entry.
+type AuthInfo struct {
+	AuthURL string `yaml:"auth_url" json:"auth_url"`
+
+	Token string `yaml:"token" json:"token"`
+
+	Username string `yaml:"username" json:"username"`
+	UserID   string `yaml:"user_id" json:"user_id"`
+	Password string `yaml:"password" json:"password"`
+
+	ProjectName string `yaml:"project_name" json:"project_name"`
+	ProjectID   string `yaml:"project_id" json:"project_id"`
+
+	// UserDomainName and UserDomainID are the domain of the user. DomainName
+	// and DomainID are used when they are not set.
+	UserDomainName string `yaml:"user_domain_name" json:"user_domain_name"`
+	UserDomainID   string `yaml:"user_domain_id" json:"user_domain_id"`
+	DomainName     string `yaml:"domain_name" json:"domain_name"`
+	DomainID       string `yaml:"domain_id" json:"domain_id"`
+
+	// AccessKey, SecretKey and SecurityToken are the credentials of the
+	// "aksk" auth type.
+	AccessKey     string `yaml:"access_key" json:"access_key"`
+	SecretKey     string `yaml:"secret_key" json:"secret_key"`
+	SecurityToken string `yaml:"security_token" json:"security_token"`
+}
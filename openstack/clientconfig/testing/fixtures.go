@@ -0,0 +1,113 @@
+package testing
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+// CloudsYAML is a clouds.yaml file with a password cloud, whose password is
+// in SecureYAML, and an AK/SK cloud.
+const CloudsYAML = `
+clouds:
+  mycloud:
+    auth:
+      auth_url: %s
+      username: me
+      user_domain_name: mydomain
+      project_id: 263fd9
+    region_name: RegionOne
+    interface: internal
+    verify: false
+    endpoint_override:
+      network: https://vpc.example.com
+  aksk:
+    auth_type: aksk
+    auth:
+      auth_url: %s
+      access_key: access
+      project_id: 263fd9
+    proxy: http://proxy.example.com:3128
+`
+
+// SecureYAML holds the secrets of the clouds in CloudsYAML.
+const SecureYAML = `
+clouds:
+  mycloud:
+    auth:
+      password: secret
+  aksk:
+    auth:
+      secret_key: secret
+`
+
+// TokenOutput is the response to a token request.
+const TokenOutput = `
+{
+    "token": {
+        "expires_at": "2099-02-02T18:30:59.000000Z",
+        "project": {
+            "id": "263fd9"
+        },
+        "catalog": []
+    }
+}
+`
+
+// SetupConfigFiles writes clouds.yaml and secure.yaml to a temporary
+// directory and points the OS_CLIENT_CONFIG_FILE and OS_CLIENT_SECURE_FILE
+// environment variables to them. The returned function removes them.
+func SetupConfigFiles(t *testing.T, authURL string) func() {
+	dir, err := ioutil.TempDir("", "clientconfig")
+	th.AssertNoErr(t, err)
+
+	clouds := filepath.Join(dir, "clouds.yaml")
+	secure := filepath.Join(dir, "secure.yaml")
+	th.AssertNoErr(t, ioutil.WriteFile(clouds, []byte(fmt.Sprintf(CloudsYAML, authURL, authURL)), 0600))
+	th.AssertNoErr(t, ioutil.WriteFile(secure, []byte(SecureYAML), 0600))
+
+	os.Setenv("OS_CLIENT_CONFIG_FILE", clouds)
+	os.Setenv("OS_CLIENT_SECURE_FILE", secure)
+
+	return func() {
+		os.Unsetenv("OS_CLIENT_CONFIG_FILE")
+		os.Unsetenv("OS_CLIENT_SECURE_FILE")
+		os.RemoveAll(dir)
+	}
+}
+
+// HandleTokenCreationSuccessfully sets up the test server to respond to a
+// token request for the mycloud cloud.
+func HandleTokenCreationSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, `
+			{
+				"auth": {
+					"identity": {
+						"methods": ["password"],
+						"password": {
+							"user": {
+								"domain": {"name": "mydomain"},
+								"name": "me",
+								"password": "secret"
+							}
+						}
+					},
+					"scope": {
+						"project": {"id": "263fd9"}
+					}
+				}
+			}
+		`)
+
+		w.Header().Add("X-Subject-Token", "0123456789")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, TokenOutput)
+	})
+}
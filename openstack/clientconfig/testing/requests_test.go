@@ -0,0 +1,114 @@
+package testing
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack"
+	"github.com/huaweicloud/golangsdk/openstack/clientconfig"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+func TestGetCloudFromYAML(t *testing.T) {
+	defer SetupConfigFiles(t, "https://iam.example.com/v3")()
+
+	cloud, err := clientconfig.GetCloudFromYAML(&clientconfig.ClientOpts{Cloud: "mycloud"})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://iam.example.com/v3", cloud.AuthInfo.AuthURL)
+	th.CheckEquals(t, "me", cloud.AuthInfo.Username)
+	th.CheckEquals(t, "secret", cloud.AuthInfo.Password)
+	th.CheckEquals(t, "RegionOne", cloud.RegionName)
+	th.CheckEquals(t, "https://vpc.example.com", cloud.EndpointOverride["network"])
+
+	_, err = clientconfig.GetCloudFromYAML(&clientconfig.ClientOpts{Cloud: "unknown"})
+	if err == nil {
+		t.Fatal("expected an error for an unknown cloud")
+	}
+}
+
+func TestAuthOptions(t *testing.T) {
+	defer SetupConfigFiles(t, "https://iam.example.com/v3")()
+
+	ao, err := clientconfig.AuthOptions(&clientconfig.ClientOpts{Cloud: "mycloud"})
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, &golangsdk.AuthOptions{
+		IdentityEndpoint: "https://iam.example.com/v3",
+		Username:         "me",
+		Password:         "secret",
+		DomainName:       "mydomain",
+		TenantID:         "263fd9",
+		AllowReauth:      true,
+	}, ao)
+
+	_, err = clientconfig.AuthOptions(&clientconfig.ClientOpts{Cloud: "aksk"})
+	if err == nil {
+		t.Fatal("expected an error for the aksk auth type")
+	}
+}
+
+func TestAKSKAuthOptions(t *testing.T) {
+	defer SetupConfigFiles(t, "https://iam.example.com/v3")()
+
+	ao, err := clientconfig.AKSKAuthOptions(&clientconfig.ClientOpts{Cloud: "aksk"})
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, &golangsdk.AKSKAuthOptions{
+		IdentityEndpoint: "https://iam.example.com/v3",
+		ProjectId:        "263fd9",
+		AccessKey:        "access",
+		SecretKey:        "secret",
+	}, ao)
+}
+
+func TestEndpointOpts(t *testing.T) {
+	defer SetupConfigFiles(t, "https://iam.example.com/v3")()
+
+	eo, err := clientconfig.EndpointOpts(&clientconfig.ClientOpts{Cloud: "mycloud"})
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, golangsdk.EndpointOpts{
+		Region:       "RegionOne",
+		Availability: golangsdk.AvailabilityInternal,
+		Overrides:    map[string]string{"network": "https://vpc.example.com"},
+	}, eo)
+}
+
+func TestHTTPClient(t *testing.T) {
+	defer SetupConfigFiles(t, "https://iam.example.com/v3")()
+
+	hc, err := clientconfig.HTTPClient(&clientconfig.ClientOpts{Cloud: "mycloud"})
+	th.AssertNoErr(t, err)
+
+	transport := hc.Transport.(*http.Transport)
+	th.CheckEquals(t, true, transport.TLSClientConfig.InsecureSkipVerify)
+
+	hc, err = clientconfig.HTTPClient(&clientconfig.ClientOpts{Cloud: "aksk"})
+	th.AssertNoErr(t, err)
+
+	transport = hc.Transport.(*http.Transport)
+	th.CheckEquals(t, false, transport.TLSClientConfig.InsecureSkipVerify)
+	proxy, err := transport.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "vpc.example.com"}})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "http://proxy.example.com:3128", proxy.String())
+}
+
+func TestAuthenticatedClient(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	defer SetupConfigFiles(t, th.Endpoint()+"v3")()
+	HandleTokenCreationSuccessfully(t)
+
+	provider, err := clientconfig.AuthenticatedClient(&clientconfig.ClientOpts{Cloud: "mycloud"})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "0123456789", provider.TokenID)
+	th.CheckEquals(t, "263fd9", provider.ProjectID)
+
+	client, err := openstack.NewNetworkV1(provider, golangsdk.EndpointOpts{})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://vpc.example.com/", client.Endpoint)
+
+	_, err = openstack.NewComputeV2(provider, golangsdk.EndpointOpts{})
+	if err == nil {
+		t.Fatal("expected an error for a service missing from the catalog")
+	}
+}
@@ -0,0 +1,86 @@
+package clientconfig
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// configDirs returns the directories searched for clouds.yaml and
+// secure.yaml, in order: the current directory, the user's configuration
+// directory and the system-wide one.
+func configDirs() []string {
+	dirs := []string{"."}
+
+	home := os.Getenv("HOME")
+	if u, err := user.Current(); err == nil && u.HomeDir != "" {
+		home = u.HomeDir
+	}
+	if home != "" {
+		dirs = append(dirs, filepath.Join(home, ".config", "openstack"))
+	}
+
+	return append(dirs, "/etc/openstack")
+}
+
+// findAndReadYAML reads the file at the path held by envVar if it is set,
+// and the first file called name in the search directories otherwise. It
+// returns nil if there is no such file.
+func findAndReadYAML(name, envVar string) (map[interface{}]interface{}, error) {
+	path := os.Getenv(envVar)
+	if path == "" {
+		for _, dir := range configDirs() {
+			p := filepath.Join(dir, name)
+			if _, err := os.Stat(p); err == nil {
+				path = p
+				break
+			}
+		}
+	}
+	if path == "" {
+		return nil, nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[interface{}]interface{}
+	if err := yaml.Unmarshal(content, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", path, err)
+	}
+	return m, nil
+}
+
+// mergeYAML merges src into dst, recursing into the mappings both have and
+// letting the values of src win otherwise.
+func mergeYAML(dst, src map[interface{}]interface{}) map[interface{}]interface{} {
+	if dst == nil {
+		dst = make(map[interface{}]interface{})
+	}
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[interface{}]interface{})
+		dstMap, dstIsMap := dst[k].(map[interface{}]interface{})
+		if srcIsMap && dstIsMap {
+			dst[k] = mergeYAML(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
+	return dst
+}
+
+// firstNonEmpty returns the first of values that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -1,8 +1,6 @@
 package alarmrule
 
 import (
-	"log"
-
 	"github.com/huaweicloud/golangsdk"
 )
 
@@ -57,14 +55,14 @@ func Create(c *golangsdk.ServiceClient, opts CreateOptsBuilder) (r CreateResult)
 		r.Err = err
 		return
 	}
-	log.Printf("[DEBUG] create AlarmRule url:%q, body=%#v, opt=%#v", rootURL(c), b, opts)
+	c.LogDebug("create AlarmRule", map[string]interface{}{"url": rootURL(c), "body": golangsdk.RedactBody(b)})
 	reqOpt := &golangsdk.RequestOpts{OkCodes: []int{201}}
-	_, r.Err = c.Post(rootURL(c), b, &r.Body, reqOpt)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Post(rootURL(c), b, &r.Body, reqOpt))
 	return
 }
 
 func Get(c *golangsdk.ServiceClient, id string) (r GetResult) {
-	_, r.Err = c.Get(resourceURL(c, id), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Get(resourceURL(c, id), &r.Body, nil))
 	return
 }
 
@@ -86,14 +84,14 @@ func Update(c *golangsdk.ServiceClient, id string, opts UpdateOpts) (r UpdateRes
 		r.Err = err
 		return
 	}
-	_, r.Err = c.Put(actionURL(c, id), b, nil, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Put(actionURL(c, id), b, nil, &golangsdk.RequestOpts{
 		OkCodes: []int{204},
-	})
+	}))
 	return
 }
 
 func Delete(c *golangsdk.ServiceClient, id string) (r DeleteResult) {
 	reqOpt := &golangsdk.RequestOpts{OkCodes: []int{204}}
-	_, r.Err = c.Delete(resourceURL(c, id), reqOpt)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Delete(resourceURL(c, id), reqOpt))
 	return
 }
@@ -7,7 +7,7 @@ import (
 
 // Get retrieves information for a specific extension using its alias.
 func Get(c *golangsdk.ServiceClient, alias string) (r GetResult) {
-	_, r.Err = c.Get(ExtensionURL(c, alias), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Get(ExtensionURL(c, alias), &r.Body, nil))
 	return
 }
 
@@ -6,6 +6,6 @@ import (
 
 // Get available zones
 func Get(client *golangsdk.ServiceClient) (r GetResult) {
-	_, r.Err = client.Get(getURL(client), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(getURL(client), &r.Body, nil))
 	return
 }
@@ -1,6 +1,8 @@
 package instances
 
 import (
+	"time"
+
 	"github.com/huaweicloud/golangsdk"
 )
 
@@ -125,16 +127,16 @@ func Create(client *golangsdk.ServiceClient, ops CreateOpsBuilder) (r CreateResu
 		return
 	}
 
-	_, r.Err = client.Post(createURL(client), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(createURL(client), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 
 	return
 }
 
 // Delete an instance by id
 func Delete(client *golangsdk.ServiceClient, id string) (r DeleteResult) {
-	_, r.Err = client.Delete(deleteURL(client, id), nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Delete(deleteURL(client, id), nil))
 	return
 }
 
@@ -185,15 +187,15 @@ func Update(client *golangsdk.ServiceClient, id string, opts UpdateOptsBuilder)
 		return
 	}
 
-	_, r.Err = client.Put(updateURL(client, id), body, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Put(updateURL(client, id), body, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{204},
-	})
+	}))
 	return
 }
 
 // Get a instance with detailed information by id
 func Get(client *golangsdk.ServiceClient, id string) (r GetResult) {
-	_, r.Err = client.Get(getURL(client, id), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(getURL(client, id), &r.Body, nil))
 	return
 }
 
@@ -233,9 +235,9 @@ func UpdatePassword(client *golangsdk.ServiceClient, id string, opts UpdatePassw
 		return
 	}
 
-	_, r.Err = client.Put(passwordURL(client, id), body, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Put(passwordURL(client, id), body, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
@@ -270,8 +272,25 @@ func Extend(client *golangsdk.ServiceClient, id string, opts ExtendOptsBuilder)
 		return
 	}
 
-	_, r.Err = client.Post(extendURL(client, id), body, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(extendURL(client, id), body, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{204},
-	})
+	}))
 	return
 }
+
+// WaitForStatus polls a DCS instance until it reaches status, such as
+// "RUNNING", or gives up after timeout. The ERROR and CREATEFAILED statuses
+// end the wait early with a golangsdk.ErrUnexpectedStatus.
+func WaitForStatus(client *golangsdk.ServiceClient, id, status string, timeout time.Duration) (*Instance, error) {
+	r, err := golangsdk.WaitForStatus(func() (interface{}, string, error) {
+		inst, err := Get(client, id).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+		return inst, inst.Status, nil
+	}, []string{status}, nil, []string{"ERROR", "CREATEFAILED"}, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return r.(*Instance), nil
+}
@@ -0,0 +1,58 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/dcs/v1/instances"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+	fake "github.com/huaweicloud/golangsdk/testhelper/client"
+)
+
+// handleGetInstance serves the instance in each of statuses in turn, staying in
+// the last one.
+func handleGetInstance(t *testing.T, statuses ...string) {
+	th.Mux.HandleFunc("/instances/3c49ba8a-1d5c-4b0e-8c84-6a5c4d9f3e21", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		status := statuses[0]
+		if len(statuses) > 1 {
+			statuses = statuses[1:]
+		}
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"instance_id": "3c49ba8a-1d5c-4b0e-8c84-6a5c4d9f3e21", "status": "%s"}`, status)
+	})
+}
+
+func TestWaitForStatus(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleGetInstance(t, "CREATING", "RUNNING")
+
+	instance, err := instances.WaitForStatus(fake.ServiceClient(), "3c49ba8a-1d5c-4b0e-8c84-6a5c4d9f3e21", "RUNNING", 10*time.Second)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "3c49ba8a-1d5c-4b0e-8c84-6a5c4d9f3e21", instance.InstanceID)
+	th.CheckEquals(t, "RUNNING", instance.Status)
+}
+
+func TestWaitForStatusFailed(t *testing.T) {
+	for _, status := range []string{"ERROR", "CREATEFAILED"} {
+		th.SetupHTTP()
+		handleGetInstance(t, status)
+
+		_, err := instances.WaitForStatus(fake.ServiceClient(), "3c49ba8a-1d5c-4b0e-8c84-6a5c4d9f3e21", "RUNNING", 10*time.Second)
+		th.TeardownHTTP()
+
+		e, ok := err.(golangsdk.ErrUnexpectedStatus)
+		if !ok {
+			t.Fatalf("expected ErrUnexpectedStatus for %s, got %v", status, err)
+		}
+		th.CheckEquals(t, status, e.Status)
+		th.CheckEquals(t, status, e.Resource.(*instances.Instance).Status)
+	}
+}
@@ -38,16 +38,16 @@ func Create(client *golangsdk.ServiceClient, queueID string, ops CreateOpsBuilde
 		return
 	}
 
-	_, r.Err = client.Post(createURL(client, queueID), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(createURL(client, queueID), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{201},
-	})
+	}))
 
 	return
 }
 
 // Delete a group by id
 func Delete(client *golangsdk.ServiceClient, queueID string, groupID string) (r DeleteResult) {
-	_, r.Err = client.Delete(deleteURL(client, queueID, groupID), nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Delete(deleteURL(client, queueID, groupID), nil))
 	return
 }
 
@@ -0,0 +1,58 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/dms/v1/instances"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+	fake "github.com/huaweicloud/golangsdk/testhelper/client"
+)
+
+// handleGetInstance serves the instance in each of statuses in turn, staying in
+// the last one.
+func handleGetInstance(t *testing.T, statuses ...string) {
+	th.Mux.HandleFunc("/instances/8f3a2c1d-5e6b-4a7c-9d8e-0f1a2b3c4d5e", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		status := statuses[0]
+		if len(statuses) > 1 {
+			statuses = statuses[1:]
+		}
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"instance_id": "8f3a2c1d-5e6b-4a7c-9d8e-0f1a2b3c4d5e", "status": "%s"}`, status)
+	})
+}
+
+func TestWaitForStatus(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleGetInstance(t, "CREATING", "RUNNING")
+
+	instance, err := instances.WaitForStatus(fake.ServiceClient(), "8f3a2c1d-5e6b-4a7c-9d8e-0f1a2b3c4d5e", "RUNNING", 10*time.Second)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "8f3a2c1d-5e6b-4a7c-9d8e-0f1a2b3c4d5e", instance.InstanceID)
+	th.CheckEquals(t, "RUNNING", instance.Status)
+}
+
+func TestWaitForStatusFailed(t *testing.T) {
+	for _, status := range []string{"ERROR", "CREATEFAILED"} {
+		th.SetupHTTP()
+		handleGetInstance(t, status)
+
+		_, err := instances.WaitForStatus(fake.ServiceClient(), "8f3a2c1d-5e6b-4a7c-9d8e-0f1a2b3c4d5e", "RUNNING", 10*time.Second)
+		th.TeardownHTTP()
+
+		e, ok := err.(golangsdk.ErrUnexpectedStatus)
+		if !ok {
+			t.Fatalf("expected ErrUnexpectedStatus for %s, got %v", status, err)
+		}
+		th.CheckEquals(t, status, e.Status)
+		th.CheckEquals(t, status, e.Resource.(*instances.Instance).Status)
+	}
+}
@@ -65,26 +65,27 @@ func Create(client *golangsdk.ServiceClient, ops CreateOpsBuilder) (r CreateResu
 		return
 	}
 
-	_, r.Err = client.Post(createURL(client), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(createURL(client), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{201},
-	})
+	}))
 
 	return
 }
 
 // Delete a queue by id
 func Delete(client *golangsdk.ServiceClient, id string) (r DeleteResult) {
-	_, r.Err = client.Delete(deleteURL(client, id), nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Delete(deleteURL(client, id), nil))
 	return
 }
 
 // Get a queue with detailed information by id
 func Get(client *golangsdk.ServiceClient, id string, includeDeadLetter bool) (r GetResult) {
-	_, r.Err = client.Get(getURL(client, id, includeDeadLetter), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(getURL(client, id, includeDeadLetter), &r.Body, nil))
 	return
 }
 
-// List all the queues
+// List all the queues. The DMS API has no paging parameters for queues and
+// returns them all in a single page.
 func List(client *golangsdk.ServiceClient, includeDeadLetter bool) pagination.Pager {
 	url := listURL(client, includeDeadLetter)
 
@@ -57,7 +57,7 @@ func ListByZone(client *golangsdk.ServiceClient, zoneID string, opts ListOptsBui
 
 // Get implements the recordset Get request.
 func Get(client *golangsdk.ServiceClient, zoneID string, rrsetID string) (r GetResult) {
-	_, r.Err = client.Get(rrsetURL(client, zoneID, rrsetID), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(rrsetURL(client, zoneID, rrsetID), &r.Body, nil))
 	return
 }
 
@@ -103,9 +103,9 @@ func Create(client *golangsdk.ServiceClient, zoneID string, opts CreateOptsBuild
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(baseURL(client, zoneID), &b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(baseURL(client, zoneID), &b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{201, 202},
-	})
+	}))
 	return
 }
 
@@ -151,16 +151,16 @@ func Update(client *golangsdk.ServiceClient, zoneID string, rrsetID string, opts
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Put(rrsetURL(client, zoneID, rrsetID), &b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Put(rrsetURL(client, zoneID, rrsetID), &b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200, 202},
-	})
+	}))
 	return
 }
 
 // Delete removes an existing RecordSet.
 func Delete(client *golangsdk.ServiceClient, zoneID string, rrsetID string) (r DeleteResult) {
-	_, r.Err = client.Delete(rrsetURL(client, zoneID, rrsetID), &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Delete(rrsetURL(client, zoneID, rrsetID), &golangsdk.RequestOpts{
 		OkCodes: []int{202},
-	})
+	}))
 	return
 }
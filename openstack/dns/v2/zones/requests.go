@@ -55,7 +55,7 @@ func List(client *golangsdk.ServiceClient, opts ListOptsBuilder) pagination.Page
 
 // Get returns information about a zone, given its ID.
 func Get(client *golangsdk.ServiceClient, zoneID string) (r GetResult) {
-	_, r.Err = client.Get(zoneURL(client, zoneID), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(zoneURL(client, zoneID), &r.Body, nil))
 	return
 }
 
@@ -110,9 +110,9 @@ func Create(client *golangsdk.ServiceClient, opts CreateOptsBuilder) (r CreateRe
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(baseURL(client), &b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(baseURL(client), &b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{201, 202},
-	})
+	}))
 	return
 }
 
@@ -158,17 +158,17 @@ func Update(client *golangsdk.ServiceClient, zoneID string, opts UpdateOptsBuild
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Patch(zoneURL(client, zoneID), &b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Patch(zoneURL(client, zoneID), &b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200, 202},
-	})
+	}))
 	return
 }
 
 // Delete implements a zone delete request.
 func Delete(client *golangsdk.ServiceClient, zoneID string) (r DeleteResult) {
-	_, r.Err = client.Delete(zoneURL(client, zoneID), &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Delete(zoneURL(client, zoneID), &golangsdk.RequestOpts{
 		OkCodes:      []int{202},
 		JSONResponse: &r.Body,
-	})
+	}))
 	return
 }
@@ -47,22 +47,22 @@ func Create(client *golangsdk.ServiceClient, ops CreateOpsBuilder) (r CreateResu
 		return
 	}
 
-	_, r.Err = client.Post(createURL(client), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(createURL(client), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{202},
-	})
+	}))
 
 	return
 }
 
 // Delete a replication consistency group by id
 func Delete(client *golangsdk.ServiceClient, id string) (r DeleteResult) {
-	_, r.Err = client.Delete(deleteURL(client, id), nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Delete(deleteURL(client, id), nil))
 	return
 }
 
 // Get a replication consistency group with detailed information by id
 func Get(client *golangsdk.ServiceClient, id string) (r GetResult) {
-	_, r.Err = client.Get(getURL(client, id), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(getURL(client, id), &r.Body, nil))
 	return
 }
 
@@ -148,9 +148,9 @@ func Update(client *golangsdk.ServiceClient, id string, opts UpdateOptsBuilder)
 		return
 	}
 
-	_, r.Err = client.Put(updateURL(client, id), body, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Put(updateURL(client, id), body, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{202},
-	})
+	}))
 	return
 }
 
@@ -176,65 +176,65 @@ const (
 
 // FailOver is performing a failover for a replication consistency group.
 func FailOver(client *golangsdk.ServiceClient, id string) (r ActionResult) {
-	_, r.Err = client.Post(
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(
 		actionURL(client, id),
 		map[string]interface{}{OsFailoverReplicationConsistencyGroup: nil},
 		nil,
 		&golangsdk.RequestOpts{
 			OkCodes: []int{200},
-		})
+		}))
 
 	return
 }
 
 // Sync is synchronizing a replication consistency group.
 func Sync(client *golangsdk.ServiceClient, id string) (r ActionResult) {
-	_, r.Err = client.Post(
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(
 		actionURL(client, id),
 		map[string]interface{}{OsSyncReplicationConsistencyGroup: nil},
 		nil,
 		&golangsdk.RequestOpts{
 			OkCodes: []int{200},
-		})
+		}))
 
 	return
 }
 
 // Reverse is performing a primary/secondary switchover for a replication consistency group.
 func Reverse(client *golangsdk.ServiceClient, id string) (r ActionResult) {
-	_, r.Err = client.Post(
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(
 		actionURL(client, id),
 		map[string]interface{}{OsReverseReplicationConsistencyGroup: nil},
 		nil,
 		&golangsdk.RequestOpts{
 			OkCodes: []int{200},
-		})
+		}))
 
 	return
 }
 
 // Stop is pausing a replication consistency group.
 func Stop(client *golangsdk.ServiceClient, id string) (r ActionResult) {
-	_, r.Err = client.Post(
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(
 		actionURL(client, id),
 		map[string]interface{}{OsStopReplicationConsistencyGroup: nil},
 		nil,
 		&golangsdk.RequestOpts{
 			OkCodes: []int{200},
-		})
+		}))
 
 	return
 }
 
 // Reprotect is reprotecting a replication consistency group.
 func Reprotect(client *golangsdk.ServiceClient, id string) (r ActionResult) {
-	_, r.Err = client.Post(
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(
 		actionURL(client, id),
 		map[string]interface{}{OsReprotectReplicationConsistencyGroup: nil},
 		nil,
 		&golangsdk.RequestOpts{
 			OkCodes: []int{200},
-		})
+		}))
 
 	return
 }
@@ -276,9 +276,9 @@ func Extend(client *golangsdk.ServiceClient, id string, ops ExtendReplicationVol
 		return
 	}
 
-	_, r.Err = client.Post(actionURL(client, id), b, nil, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(actionURL(client, id), b, nil, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 
 	return
 }
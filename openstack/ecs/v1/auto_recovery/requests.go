@@ -1,8 +1,6 @@
 package auto_recovery
 
 import (
-	"log"
-
 	"github.com/huaweicloud/golangsdk"
 )
 
@@ -23,7 +21,7 @@ func Update(c *golangsdk.ServiceClient, id string, opts UpdateOptsBuilder) error
 	if err != nil {
 		return err
 	}
-	log.Printf("[DEBUG] update url:%q, body=%#v", updateURL(c, id), b)
+	c.LogDebug("update ECS auto recovery", map[string]interface{}{"url": updateURL(c, id), "body": golangsdk.RedactBody(b)})
 	_, err = c.Put(updateURL(c, id), b, nil, &golangsdk.RequestOpts{
 		OkCodes: []int{204},
 	})
@@ -31,6 +29,6 @@ func Update(c *golangsdk.ServiceClient, id string, opts UpdateOptsBuilder) error
 }
 
 func Get(c *golangsdk.ServiceClient, id string) (r GetResult) {
-	_, r.Err = c.Get(resourceURL(c, id), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Get(resourceURL(c, id), &r.Body, nil))
 	return
 }
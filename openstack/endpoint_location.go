@@ -1,6 +1,8 @@
 package openstack
 
 import (
+	"fmt"
+
 	"github.com/huaweicloud/golangsdk"
 	tokens2 "github.com/huaweicloud/golangsdk/openstack/identity/v2/tokens"
 	tokens3 "github.com/huaweicloud/golangsdk/openstack/identity/v3/tokens"
@@ -105,3 +107,54 @@ func V3EndpointURL(catalog *tokens3.ServiceCatalog, opts golangsdk.EndpointOpts)
 	err := &golangsdk.ErrEndpointNotFound{}
 	return "", err
 }
+
+// resolveEndpoint returns the endpoint of the service of type serviceType,
+// whose endpoint is derived by derive, when it is not nil, from the endpoint
+// of catalogType. It uses the override of serviceType in eo first, then the
+// override or the service catalog endpoint of catalogType, then the template
+// in eo. Without a template, the error of the service catalog is returned
+// unchanged; ErrEndpointNotResolved reports the failure of every other source.
+func resolveEndpoint(client *golangsdk.ProviderClient, eo golangsdk.EndpointOpts, serviceType, catalogType string, derive func(string) string) (string, error) {
+	if derive == nil {
+		derive = func(url string) string { return url }
+	}
+
+	if serviceType != catalogType {
+		if url, ok := eo.Overrides[serviceType]; ok {
+			return golangsdk.NormalizeURL(url), nil
+		}
+	}
+	if url, ok := eo.Overrides[catalogType]; ok {
+		return derive(golangsdk.NormalizeURL(url)), nil
+	}
+
+	e := ErrEndpointNotResolved{Type: serviceType}
+	e.Tried = append(e.Tried, "no endpoint override")
+
+	if client.EndpointLocator != nil {
+		catalogOpts := eo
+		catalogOpts.Type = catalogType
+		url, err := client.EndpointLocator(catalogOpts)
+		if err == nil {
+			return derive(url), nil
+		}
+		if eo.Template == "" {
+			return "", err
+		}
+		e.Err = err
+		e.Tried = append(e.Tried, fmt.Sprintf("service catalog (type %s): %s", catalogType, err))
+	} else {
+		e.Tried = append(e.Tried, "no service catalog")
+	}
+
+	if eo.Template == "" {
+		e.Tried = append(e.Tried, "no endpoint template")
+		return "", e
+	}
+	url, err := eo.TemplateEndpoint(serviceType)
+	if err != nil {
+		e.Tried = append(e.Tried, fmt.Sprintf("endpoint template %s: %s", eo.Template, err))
+		return "", e
+	}
+	return url, nil
+}
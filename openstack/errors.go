@@ -2,6 +2,7 @@ package openstack
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/huaweicloud/golangsdk"
 	tokens2 "github.com/huaweicloud/golangsdk/openstack/identity/v2/tokens"
@@ -16,6 +17,25 @@ func (e ErrEndpointNotFound) Error() string {
 	return "No suitable endpoint could be found in the service catalog."
 }
 
+// ErrEndpointNotResolved is the error when no endpoint can be resolved for
+// a service type. Tried describes each source that was tried, in order, and
+// Err is the error of the service catalog, if it was searched.
+type ErrEndpointNotResolved struct {
+	golangsdk.BaseError
+	Type  string
+	Tried []string
+	Err   error
+}
+
+func (e ErrEndpointNotResolved) Error() string {
+	return fmt.Sprintf("No endpoint could be resolved for service type %s: %s", e.Type, strings.Join(e.Tried, "; "))
+}
+
+// Unwrap returns the error of the service catalog.
+func (e ErrEndpointNotResolved) Unwrap() error {
+	return e.Err
+}
+
 // ErrInvalidAvailabilityProvided is the error when an invalid endpoint
 // availability is provided
 type ErrInvalidAvailabilityProvided struct{ golangsdk.ErrInvalidInput }
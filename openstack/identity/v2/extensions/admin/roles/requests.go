@@ -17,9 +17,9 @@ func List(client *golangsdk.ServiceClient) pagination.Pager {
 // a user. This is confined to the scope of the user's tenant - so the tenant
 // ID is a required argument.
 func AddUser(client *golangsdk.ServiceClient, tenantID, userID, roleID string) (r UserRoleResult) {
-	_, r.Err = client.Put(userRoleURL(client, tenantID, userID, roleID), nil, nil, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Put(userRoleURL(client, tenantID, userID, roleID), nil, nil, &golangsdk.RequestOpts{
 		OkCodes: []int{200, 201},
-	})
+	}))
 	return
 }
 
@@ -27,6 +27,6 @@ func AddUser(client *golangsdk.ServiceClient, tenantID, userID, roleID string) (
 // from a user. This is confined to the scope of the user's tenant - so the
 // tenant ID is a required argument.
 func DeleteUser(client *golangsdk.ServiceClient, tenantID, userID, roleID string) (r UserRoleResult) {
-	_, r.Err = client.Delete(userRoleURL(client, tenantID, userID, roleID), nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Delete(userRoleURL(client, tenantID, userID, roleID), nil))
 	return
 }
@@ -1,6 +1,10 @@
 package tokens
 
-import "github.com/huaweicloud/golangsdk"
+import (
+	"context"
+
+	"github.com/huaweicloud/golangsdk"
+)
 
 // PasswordCredentialsV2 represents the required options to authenticate
 // with a username and password.
@@ -82,22 +86,27 @@ func (opts AuthOptions) ToTokenV2CreateMap() (map[string]interface{}, error) {
 // call openstack.AuthenticatedClient(), which abstracts all of the gory details
 // about navigating service catalogs and such.
 func Create(client *golangsdk.ServiceClient, auth AuthOptionsBuilder) (r CreateResult) {
+	return CreateWithContext(context.Background(), client, auth)
+}
+
+// CreateWithContext is like Create, but binds the token request to ctx.
+func CreateWithContext(ctx context.Context, client *golangsdk.ServiceClient, auth AuthOptionsBuilder) (r CreateResult) {
 	b, err := auth.ToTokenV2CreateMap()
 	if err != nil {
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(CreateURL(client), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.PostCtx(ctx, CreateURL(client), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes:     []int{200, 203},
 		MoreHeaders: map[string]string{"X-Auth-Token": ""},
-	})
+	}))
 	return
 }
 
 // Get validates and retrieves information for user's token.
 func Get(client *golangsdk.ServiceClient, token string) (r GetResult) {
-	_, r.Err = client.Get(GetURL(client, token), &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(GetURL(client, token), &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200, 203},
-	})
+	}))
 	return
 }
@@ -0,0 +1,23 @@
+/*
+Package catalog provides the service catalog of the caller through the
+Identity v3 auth/catalog API. It works with any authentication method, which
+makes it the way to discover endpoints when requests are signed with AK/SK
+and no token is ever issued.
+
+Example to List the Service Catalog
+
+	allPages, err := catalog.List(identityClient).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	entries, err := catalog.ExtractServiceCatalog(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, entry := range entries {
+		fmt.Printf("%+v\n", entry)
+	}
+*/
+package catalog
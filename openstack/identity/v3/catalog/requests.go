@@ -0,0 +1,13 @@
+package catalog
+
+import (
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/pagination"
+)
+
+// List enumerates the services and endpoints available to the caller.
+func List(client *golangsdk.ServiceClient) pagination.Pager {
+	return pagination.NewPager(client, listURL(client), func(r pagination.PageResult) pagination.Page {
+		return ServiceCatalogPage{pagination.SinglePageBase(r)}
+	})
+}
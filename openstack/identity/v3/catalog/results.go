@@ -0,0 +1,26 @@
+package catalog
+
+import (
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/tokens"
+	"github.com/huaweicloud/golangsdk/pagination"
+)
+
+// ServiceCatalogPage is a single page of service catalog entries.
+type ServiceCatalogPage struct {
+	pagination.SinglePageBase
+}
+
+// IsEmpty determines whether or not a page of catalog entries contains any
+// results.
+func (r ServiceCatalogPage) IsEmpty() (bool, error) {
+	entries, err := ExtractServiceCatalog(r)
+	return len(entries) == 0, err
+}
+
+// ExtractServiceCatalog returns the catalog entries contained in a page of
+// results.
+func ExtractServiceCatalog(r pagination.Page) ([]tokens.CatalogEntry, error) {
+	var s tokens.ServiceCatalog
+	err := (r.(ServiceCatalogPage)).ExtractInto(&s)
+	return s.Entries, err
+}
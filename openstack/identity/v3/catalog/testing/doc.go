@@ -0,0 +1,2 @@
+// catalog unit tests
+package testing
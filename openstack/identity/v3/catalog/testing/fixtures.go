@@ -0,0 +1,64 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/tokens"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+	"github.com/huaweicloud/golangsdk/testhelper/client"
+)
+
+// ListOutput provides a single page of catalog entries.
+const ListOutput = `
+{
+    "catalog": [
+        {
+            "endpoints": [
+                {
+                    "id": "39dc322ce86c4111b4f06c2eeae0841b",
+                    "interface": "public",
+                    "region": "RegionOne",
+                    "url": "https://vpc.example.com/"
+                }
+            ],
+            "id": "9a9ba7cbc3b14ed3a0c6d1d3ab0d0d8d",
+            "name": "vpc",
+            "type": "network"
+        }
+    ],
+    "links": {
+        "self": "https://iam.example.com/v3/auth/catalog"
+    }
+}
+`
+
+// NetworkEntry is the expected catalog entry in ListOutput.
+var NetworkEntry = tokens.CatalogEntry{
+	ID:   "9a9ba7cbc3b14ed3a0c6d1d3ab0d0d8d",
+	Name: "vpc",
+	Type: "network",
+	Endpoints: []tokens.Endpoint{
+		{
+			ID:        "39dc322ce86c4111b4f06c2eeae0841b",
+			Interface: "public",
+			Region:    "RegionOne",
+			URL:       "https://vpc.example.com/",
+		},
+	},
+}
+
+// HandleListCatalogSuccessfully creates an HTTP handler at `/auth/catalog` on
+// the test handler mux that responds with a list of catalog entries.
+func HandleListCatalogSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/auth/catalog", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, ListOutput)
+	})
+}
@@ -0,0 +1,23 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/catalog"
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/tokens"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+	"github.com/huaweicloud/golangsdk/testhelper/client"
+)
+
+func TestListCatalog(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListCatalogSuccessfully(t)
+
+	allPages, err := catalog.List(client.ServiceClient()).AllPages()
+	th.AssertNoErr(t, err)
+
+	actual, err := catalog.ExtractServiceCatalog(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []tokens.CatalogEntry{NetworkEntry}, actual)
+}
@@ -42,7 +42,7 @@ func Create(client *golangsdk.ServiceClient, opts CreateOptsBuilder) (r CreateRe
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(listURL(client), &b, &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(listURL(client), &b, &r.Body, nil))
 	return
 }
 
@@ -128,12 +128,12 @@ func Update(client *golangsdk.ServiceClient, endpointID string, opts UpdateOptsB
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Patch(endpointURL(client, endpointID), &b, &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Patch(endpointURL(client, endpointID), &b, &r.Body, nil))
 	return
 }
 
 // Delete removes an endpoint from the service catalog.
 func Delete(client *golangsdk.ServiceClient, endpointID string) (r DeleteResult) {
-	_, r.Err = client.Delete(endpointURL(client, endpointID), nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Delete(endpointURL(client, endpointID), nil))
 	return
 }
@@ -0,0 +1,44 @@
+/*
+Package agency enables authentication through an IAM agency: a delegation
+that lets the users of one account assume a role in the projects of another
+account, the one that created the agency.
+
+The token request for the agency is authenticated with a token of the
+delegated user, which tokens.Create obtains first from the embedded
+AuthOptionsBuilder.
+
+Example to Create a Token for an Agency
+
+	authOptions := tokens.AuthOptions{
+		Username:   "username",
+		Password:   "password",
+		DomainName: "ops",
+	}
+
+	createOpts := agency.AuthOptsExt{
+		AuthOptionsBuilder: &authOptions,
+		DomainName:         "customer",
+		AgencyName:         "ops-agency",
+		ProjectName:        "cn-north-1",
+	}
+
+	token, err := tokens.Create(identityClient, createOpts).ExtractToken()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Authenticate a Provider Client through an Agency
+
+	authOptions.AllowReauth = true
+
+	provider, err := openstack.NewClient("https://iam.example.com/v3")
+	if err != nil {
+		panic(err)
+	}
+
+	err = openstack.AuthenticateV3(provider, createOpts, golangsdk.EndpointOpts{})
+	if err != nil {
+		panic(err)
+	}
+*/
+package agency
@@ -0,0 +1,19 @@
+package agency
+
+import "github.com/huaweicloud/golangsdk"
+
+// ErrDomainIDOrDomainName indicates that both or neither of DomainID and
+// DomainName were provided for the agency.
+type ErrDomainIDOrDomainName struct{ golangsdk.BaseError }
+
+func (e ErrDomainIDOrDomainName) Error() string {
+	return "You must provide exactly one of DomainID or DomainName of the agency"
+}
+
+// ErrProjectIDOrProjectName indicates that both a ProjectID and a
+// ProjectName were provided for the agency.
+type ErrProjectIDOrProjectName struct{ golangsdk.BaseError }
+
+func (e ErrProjectIDOrProjectName) Error() string {
+	return "You must provide at most one of ProjectID or ProjectName of the agency"
+}
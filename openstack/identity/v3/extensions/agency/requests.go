@@ -0,0 +1,109 @@
+package agency
+
+import (
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/tokens"
+)
+
+// AuthOptsExt extends the base Identity v3 tokens AuthOpts with the agency
+// to assume. The embedded AuthOptionsBuilder holds the credentials of the
+// delegated user.
+type AuthOptsExt struct {
+	tokens.AuthOptionsBuilder
+
+	// DomainID or DomainName identifies the account that created the
+	// agency. Exactly one of them must be provided.
+	DomainID   string
+	DomainName string
+
+	// AgencyName is the name of the agency. It is required.
+	AgencyName string
+
+	// ProjectID or ProjectName is the project of the delegating account to
+	// scope the token to. The token is scoped to the domain of the
+	// delegating account when both are empty.
+	ProjectID   string
+	ProjectName string
+}
+
+// ToTokenV3CreateMap builds a create request body for the assume_role
+// method. The identity of the embedded AuthOptionsBuilder is not part of it.
+func (opts AuthOptsExt) ToTokenV3CreateMap(scope map[string]interface{}) (map[string]interface{}, error) {
+	if opts.AgencyName == "" {
+		return nil, golangsdk.ErrMissingInput{Argument: "AgencyName"}
+	}
+
+	assumeRole := map[string]interface{}{
+		"xrole_name": opts.AgencyName,
+	}
+	switch {
+	case opts.DomainID != "" && opts.DomainName != "":
+		return nil, ErrDomainIDOrDomainName{}
+	case opts.DomainID != "":
+		assumeRole["domain_id"] = opts.DomainID
+	case opts.DomainName != "":
+		assumeRole["domain_name"] = opts.DomainName
+	default:
+		return nil, ErrDomainIDOrDomainName{}
+	}
+
+	auth := map[string]interface{}{
+		"identity": map[string]interface{}{
+			"methods":     []string{"assume_role"},
+			"assume_role": assumeRole,
+		},
+	}
+	if len(scope) != 0 {
+		auth["scope"] = scope
+	}
+
+	return map[string]interface{}{"auth": auth}, nil
+}
+
+// ToTokenV3ScopeMap builds a scope for the project, or the domain, of the
+// agency.
+func (opts AuthOptsExt) ToTokenV3ScopeMap() (map[string]interface{}, error) {
+	if opts.ProjectID != "" && opts.ProjectName != "" {
+		return nil, ErrProjectIDOrProjectName{}
+	}
+
+	if opts.ProjectID != "" {
+		return map[string]interface{}{
+			"project": map[string]interface{}{
+				"id": opts.ProjectID,
+			},
+		}, nil
+	}
+
+	domain := map[string]interface{}{}
+	if opts.DomainID != "" {
+		domain["id"] = opts.DomainID
+	} else {
+		domain["name"] = opts.DomainName
+	}
+
+	if opts.ProjectName != "" {
+		return map[string]interface{}{
+			"project": map[string]interface{}{
+				"name":   opts.ProjectName,
+				"domain": domain,
+			},
+		}, nil
+	}
+
+	return map[string]interface{}{
+		"domain": domain,
+	}, nil
+}
+
+// CanReauth reports whether the credentials of the delegated user allow
+// re-authentication.
+func (opts AuthOptsExt) CanReauth() bool {
+	return opts.AuthOptionsBuilder.CanReauth()
+}
+
+// SourceAuthOptions returns the credentials of the delegated user, which
+// authenticate the token request for the agency.
+func (opts AuthOptsExt) SourceAuthOptions() tokens.AuthOptionsBuilder {
+	return opts.AuthOptionsBuilder
+}
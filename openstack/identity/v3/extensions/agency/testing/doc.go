@@ -0,0 +1,2 @@
+// agency unit tests
+package testing
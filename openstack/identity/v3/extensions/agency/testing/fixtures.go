@@ -0,0 +1,102 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+// SourceTokenRequest is the token request of the delegated user.
+const SourceTokenRequest = `
+{
+    "auth": {
+        "identity": {
+            "methods": ["password"],
+            "password": {
+                "user": {
+                    "name": "ops-user",
+                    "password": "secret",
+                    "domain": {"name": "ops"}
+                }
+            }
+        }
+    }
+}
+`
+
+// AgencyTokenRequest is the token request for the agency.
+const AgencyTokenRequest = `
+{
+    "auth": {
+        "identity": {
+            "methods": ["assume_role"],
+            "assume_role": {
+                "domain_name": "customer",
+                "xrole_name": "ops-agency"
+            }
+        },
+        "scope": {
+            "project": {
+                "name": "cn-north-1",
+                "domain": {"name": "customer"}
+            }
+        }
+    }
+}
+`
+
+// AgencyTokenOutput is the response to AgencyTokenRequest.
+const AgencyTokenOutput = `
+{
+    "token": {
+        "expires_at": "2099-02-27T18:30:59.999999Z",
+        "methods": ["assume_role"],
+        "project": {
+            "id": "263fd9",
+            "name": "cn-north-1"
+        },
+        "catalog": []
+    }
+}
+`
+
+// HandleAgencyTokenCreation sets up the test server to issue a token for
+// the delegated user, then a token for the agency, under path. It returns a
+// pointer to the number of requests received.
+func HandleAgencyTokenCreation(t *testing.T, path string) *int {
+	requests := 0
+	th.Mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		th.TestMethod(t, r, "POST")
+
+		var body struct {
+			Auth struct {
+				Identity struct {
+					Methods []string `json:"methods"`
+				} `json:"identity"`
+			} `json:"auth"`
+		}
+		raw := new(json.RawMessage)
+		th.AssertNoErr(t, json.NewDecoder(r.Body).Decode(raw))
+		th.AssertNoErr(t, json.Unmarshal(*raw, &body))
+
+		if body.Auth.Identity.Methods[0] == "password" {
+			th.TestHeader(t, r, "X-Auth-Token", "")
+			th.AssertJSONEquals(t, SourceTokenRequest, raw)
+			w.Header().Add("X-Subject-Token", "source-token")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token": {"expires_at": "2099-02-27T18:30:59.999999Z"}}`)
+			return
+		}
+
+		th.TestHeader(t, r, "X-Auth-Token", "source-token")
+		th.AssertJSONEquals(t, AgencyTokenRequest, raw)
+		w.Header().Add("X-Subject-Token", "agency-token")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, AgencyTokenOutput)
+	})
+	return &requests
+}
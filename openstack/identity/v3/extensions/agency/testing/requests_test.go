@@ -0,0 +1,97 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack"
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/extensions/agency"
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/tokens"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+	"github.com/huaweicloud/golangsdk/testhelper/client"
+)
+
+func agencyOptions(allowReauth bool) agency.AuthOptsExt {
+	return agency.AuthOptsExt{
+		AuthOptionsBuilder: &tokens.AuthOptions{
+			Username:    "ops-user",
+			Password:    "secret",
+			DomainName:  "ops",
+			AllowReauth: allowReauth,
+		},
+		DomainName:  "customer",
+		AgencyName:  "ops-agency",
+		ProjectName: "cn-north-1",
+	}
+}
+
+func TestCreateAgencyToken(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	requests := HandleAgencyTokenCreation(t, "/auth/tokens")
+
+	result := tokens.Create(client.ServiceClient(), agencyOptions(false))
+	token, err := result.ExtractToken()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "agency-token", token.ID)
+
+	project, err := result.ExtractProject()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "263fd9", project.ID)
+	th.CheckEquals(t, 2, *requests)
+}
+
+func TestAuthenticateV3Agency(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	requests := HandleAgencyTokenCreation(t, "/v3/auth/tokens")
+
+	provider, err := openstack.NewClient(th.Endpoint() + "v3")
+	th.AssertNoErr(t, err)
+
+	err = openstack.AuthenticateV3(provider, agencyOptions(true), golangsdk.EndpointOpts{})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "agency-token", provider.Token())
+	th.CheckEquals(t, "263fd9", provider.ProjectID)
+	th.CheckEquals(t, 2, *requests)
+
+	th.AssertNoErr(t, provider.ReauthFunc())
+	th.CheckEquals(t, "agency-token", provider.Token())
+	th.CheckEquals(t, 4, *requests)
+}
+
+func TestAgencyOptionsErrors(t *testing.T) {
+	opts := agencyOptions(false)
+	opts.AgencyName = ""
+	_, err := opts.ToTokenV3CreateMap(nil)
+	if _, ok := err.(golangsdk.ErrMissingInput); !ok {
+		t.Errorf("expected ErrMissingInput, got %T: %v", err, err)
+	}
+
+	opts = agencyOptions(false)
+	opts.DomainID = "dom1"
+	_, err = opts.ToTokenV3CreateMap(nil)
+	if _, ok := err.(agency.ErrDomainIDOrDomainName); !ok {
+		t.Errorf("expected ErrDomainIDOrDomainName, got %T: %v", err, err)
+	}
+
+	opts = agencyOptions(false)
+	opts.ProjectID = "263fd9"
+	_, err = opts.ToTokenV3ScopeMap()
+	if _, ok := err.(agency.ErrProjectIDOrProjectName); !ok {
+		t.Errorf("expected ErrProjectIDOrProjectName, got %T: %v", err, err)
+	}
+}
+
+func TestAgencyDomainScope(t *testing.T) {
+	opts := agencyOptions(false)
+	opts.ProjectName = ""
+	opts.DomainName = ""
+	opts.DomainID = "dom1"
+
+	scope, err := opts.ToTokenV3ScopeMap()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, map[string]interface{}{
+		"domain": map[string]interface{}{"id": "dom1"},
+	}, scope)
+}
@@ -0,0 +1,53 @@
+/*
+Package federation enables authentication through a federated identity
+provider, such as a corporate OIDC or SAML identity provider, registered in
+IAM.
+
+The credentials issued by the identity provider are exchanged for an
+unscoped token, which is then rescoped to a project with tokens.Create.
+
+Example to Create an Unscoped Token from an OIDC Access Token
+
+	authOptions := federation.AuthOptions{
+		IdentityProvider: "corp-idp",
+		Protocol:         "oidc",
+		AccessToken:      "{access_token}",
+	}
+
+	unscoped, err := federation.Create(identityClient, authOptions).ExtractToken()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Rescope the Token to a Project
+
+	rescopeOptions := tokens.AuthOptions{
+		TokenID: unscoped.ID,
+		Scope:   tokens.Scope{ProjectID: "{project_id}"},
+	}
+
+	token, err := tokens.Create(identityClient, &rescopeOptions).ExtractToken()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Authenticate a Provider Client with a SAML Assertion
+
+	authOptions := federation.AuthOptions{
+		IdentityProvider: "corp-idp",
+		Protocol:         "saml",
+		Assertion:        assertion,
+		Scope:            tokens.Scope{ProjectID: "{project_id}"},
+	}
+
+	provider, err := openstack.NewClient("https://iam.example.com/v3")
+	if err != nil {
+		panic(err)
+	}
+
+	err = openstack.AuthenticateFederated(provider, authOptions, golangsdk.EndpointOpts{})
+	if err != nil {
+		panic(err)
+	}
+*/
+package federation
@@ -0,0 +1,11 @@
+package federation
+
+import "github.com/huaweicloud/golangsdk"
+
+// ErrAccessTokenOrAssertion indicates that both or neither of AccessToken
+// and Assertion were provided.
+type ErrAccessTokenOrAssertion struct{ golangsdk.BaseError }
+
+func (e ErrAccessTokenOrAssertion) Error() string {
+	return "You must provide exactly one of AccessToken or Assertion"
+}
@@ -0,0 +1,77 @@
+package federation
+
+import (
+	"context"
+	"strings"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/tokens"
+)
+
+// AuthOptions holds the credentials issued to a user by a federated
+// identity provider, and the scope of the token to issue for them.
+type AuthOptions struct {
+	// IdentityProvider is the ID of the identity provider registered in IAM.
+	// It is required.
+	IdentityProvider string
+
+	// Protocol is the ID of the protocol of the identity provider, such as
+	// "oidc" or "saml". It is required.
+	Protocol string
+
+	// AccessToken is an OIDC access token, sent as a bearer token. Exactly
+	// one of AccessToken and Assertion must be provided.
+	AccessToken string
+
+	// Assertion is a SAML assertion, sent as an enhanced client or proxy
+	// (ECP) SOAP envelope.
+	Assertion string
+
+	// Scope is the project or domain to rescope the unscoped token to. The
+	// token stays unscoped when it is empty.
+	Scope tokens.Scope
+
+	// AllowReauth allows the provider client to exchange the credentials
+	// again when its token expires. The identity provider must still accept
+	// them by then.
+	AllowReauth bool
+}
+
+// Create exchanges the credentials of a federated user for an unscoped
+// token. Use tokens.Create with the ID of that token and a Scope to rescope
+// it.
+func Create(c *golangsdk.ServiceClient, opts AuthOptions) (r tokens.CreateResult) {
+	return CreateWithContext(context.Background(), c, opts)
+}
+
+// CreateWithContext is like Create, but binds the token request to ctx.
+func CreateWithContext(ctx context.Context, c *golangsdk.ServiceClient, opts AuthOptions) (r tokens.CreateResult) {
+	if opts.IdentityProvider == "" {
+		r.Err = golangsdk.ErrMissingInput{Argument: "IdentityProvider"}
+		return
+	}
+	if opts.Protocol == "" {
+		r.Err = golangsdk.ErrMissingInput{Argument: "Protocol"}
+		return
+	}
+
+	reqOpts := &golangsdk.RequestOpts{
+		MoreHeaders: map[string]string{"X-Auth-Token": ""},
+	}
+	switch {
+	case opts.AccessToken != "" && opts.Assertion != "":
+		r.Err = ErrAccessTokenOrAssertion{}
+		return
+	case opts.AccessToken != "":
+		reqOpts.MoreHeaders["Authorization"] = "Bearer " + opts.AccessToken
+	case opts.Assertion != "":
+		reqOpts.RawBody = strings.NewReader(opts.Assertion)
+		reqOpts.MoreHeaders["Content-Type"] = "application/vnd.paos+xml"
+	default:
+		r.Err = ErrAccessTokenOrAssertion{}
+		return
+	}
+
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.PostCtx(ctx, authURL(c, opts.IdentityProvider, opts.Protocol), nil, &r.Body, reqOpts))
+	return
+}
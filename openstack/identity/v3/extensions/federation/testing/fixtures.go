@@ -0,0 +1,124 @@
+package testing
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+// SAMLAssertion is the ECP envelope exchanged for an unscoped token.
+const SAMLAssertion = `<S:Envelope xmlns:S="http://schemas.xmlsoap.org/soap/envelope/"><S:Body><samlp:Response/></S:Body></S:Envelope>`
+
+// UnscopedTokenOutput is the response to a federated authentication.
+const UnscopedTokenOutput = `
+{
+    "token": {
+        "expires_at": "2099-02-27T18:30:59.999999Z",
+        "methods": ["mapped"],
+        "user": {
+            "id": "fed-user",
+            "name": "alice@example.com"
+        }
+    }
+}
+`
+
+// RescopeTokenRequest is the request rescoping the unscoped token.
+const RescopeTokenRequest = `
+{
+    "auth": {
+        "identity": {
+            "methods": ["token"],
+            "token": {"id": "unscoped-token"}
+        },
+        "scope": {
+            "project": {"id": "263fd9"}
+        }
+    }
+}
+`
+
+// ScopedTokenOutput is the response to RescopeTokenRequest.
+const ScopedTokenOutput = `
+{
+    "token": {
+        "expires_at": "2099-02-27T18:30:59.999999Z",
+        "methods": ["token"],
+        "project": {
+            "id": "263fd9",
+            "name": "cn-north-1"
+        },
+        "catalog": [
+            {
+                "type": "compute",
+                "name": "ecs",
+                "endpoints": [
+                    {
+                        "id": "1",
+                        "interface": "public",
+                        "region": "cn-north-1",
+                        "region_id": "cn-north-1",
+                        "url": "https://ecs.example.com/v2/263fd9"
+                    }
+                ]
+            }
+        ]
+    }
+}
+`
+
+// HandleOIDCAuth sets up the test server to exchange an OIDC access token
+// for an unscoped token under prefix. It returns a pointer to the number
+// of requests received.
+func HandleOIDCAuth(t *testing.T, prefix string) *int {
+	requests := 0
+	th.Mux.HandleFunc(prefix+"OS-FEDERATION/identity_providers/corp-idp/protocols/oidc/auth", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Authorization", "Bearer oidc-access-token")
+		th.TestHeader(t, r, "X-Auth-Token", "")
+
+		w.Header().Add("X-Subject-Token", "unscoped-token")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, UnscopedTokenOutput)
+	})
+	return &requests
+}
+
+// HandleSAMLAuth sets up the test server to exchange SAMLAssertion for an
+// unscoped token.
+func HandleSAMLAuth(t *testing.T) {
+	th.Mux.HandleFunc("/OS-FEDERATION/identity_providers/corp-idp/protocols/saml/auth", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Content-Type", "application/vnd.paos+xml")
+		th.TestHeader(t, r, "Authorization", "")
+
+		body, err := ioutil.ReadAll(r.Body)
+		th.AssertNoErr(t, err)
+		th.CheckEquals(t, SAMLAssertion, string(body))
+
+		w.Header().Add("X-Subject-Token", "unscoped-token")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, UnscopedTokenOutput)
+	})
+}
+
+// HandleRescope sets up the test server to rescope the unscoped token under
+// prefix. It returns a pointer to the number of requests received.
+func HandleRescope(t *testing.T, prefix string) *int {
+	requests := 0
+	th.Mux.HandleFunc(prefix+"auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", "")
+		th.TestJSONRequest(t, r, RescopeTokenRequest)
+
+		w.Header().Add("X-Subject-Token", "scoped-token")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, ScopedTokenOutput)
+	})
+	return &requests
+}
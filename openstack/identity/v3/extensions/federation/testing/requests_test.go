@@ -0,0 +1,116 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack"
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/extensions/federation"
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/tokens"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+	"github.com/huaweicloud/golangsdk/testhelper/client"
+)
+
+func TestCreateOIDC(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	requests := HandleOIDCAuth(t, "/")
+
+	token, err := federation.Create(client.ServiceClient(), federation.AuthOptions{
+		IdentityProvider: "corp-idp",
+		Protocol:         "oidc",
+		AccessToken:      "oidc-access-token",
+	}).ExtractToken()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "unscoped-token", token.ID)
+	th.CheckEquals(t, 1, *requests)
+}
+
+func TestCreateSAML(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleSAMLAuth(t)
+
+	token, err := federation.Create(client.ServiceClient(), federation.AuthOptions{
+		IdentityProvider: "corp-idp",
+		Protocol:         "saml",
+		Assertion:        SAMLAssertion,
+	}).ExtractToken()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "unscoped-token", token.ID)
+}
+
+func TestCreateErrors(t *testing.T) {
+	opts := federation.AuthOptions{Protocol: "oidc", AccessToken: "oidc-access-token"}
+	err := federation.Create(client.ServiceClient(), opts).Err
+	if _, ok := err.(golangsdk.ErrMissingInput); !ok {
+		t.Errorf("expected ErrMissingInput, got %T: %v", err, err)
+	}
+
+	opts = federation.AuthOptions{IdentityProvider: "corp-idp", Protocol: "oidc"}
+	err = federation.Create(client.ServiceClient(), opts).Err
+	if _, ok := err.(federation.ErrAccessTokenOrAssertion); !ok {
+		t.Errorf("expected ErrAccessTokenOrAssertion, got %T: %v", err, err)
+	}
+
+	opts.AccessToken = "oidc-access-token"
+	opts.Assertion = SAMLAssertion
+	err = federation.Create(client.ServiceClient(), opts).Err
+	if _, ok := err.(federation.ErrAccessTokenOrAssertion); !ok {
+		t.Errorf("expected ErrAccessTokenOrAssertion, got %T: %v", err, err)
+	}
+}
+
+func TestAuthenticateFederated(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	exchanges := HandleOIDCAuth(t, "/v3/")
+	rescopes := HandleRescope(t, "/v3/")
+
+	provider, err := openstack.NewClient(th.Endpoint() + "v3")
+	th.AssertNoErr(t, err)
+
+	err = openstack.AuthenticateFederated(provider, federation.AuthOptions{
+		IdentityProvider: "corp-idp",
+		Protocol:         "oidc",
+		AccessToken:      "oidc-access-token",
+		Scope:            tokens.Scope{ProjectID: "263fd9"},
+		AllowReauth:      true,
+	}, golangsdk.EndpointOpts{})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "scoped-token", provider.Token())
+	th.CheckEquals(t, "263fd9", provider.ProjectID)
+	th.CheckEquals(t, 1, *exchanges)
+	th.CheckEquals(t, 1, *rescopes)
+
+	url, err := provider.EndpointLocator(golangsdk.EndpointOpts{
+		Type:         "compute",
+		Region:       "cn-north-1",
+		Availability: golangsdk.AvailabilityPublic,
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://ecs.example.com/v2/263fd9/", url)
+
+	th.AssertNoErr(t, provider.ReauthFunc())
+	th.CheckEquals(t, "scoped-token", provider.Token())
+	th.CheckEquals(t, 2, *exchanges)
+	th.CheckEquals(t, 2, *rescopes)
+}
+
+func TestAuthenticateFederatedUnscoped(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleOIDCAuth(t, "/v3/")
+
+	provider, err := openstack.NewClient(th.Endpoint() + "v3")
+	th.AssertNoErr(t, err)
+
+	err = openstack.AuthenticateFederated(provider, federation.AuthOptions{
+		IdentityProvider: "corp-idp",
+		Protocol:         "oidc",
+		AccessToken:      "oidc-access-token",
+	}, golangsdk.EndpointOpts{})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "unscoped-token", provider.Token())
+	th.CheckEquals(t, "", provider.ProjectID)
+}
@@ -0,0 +1,7 @@
+package federation
+
+import "github.com/huaweicloud/golangsdk"
+
+func authURL(c *golangsdk.ServiceClient, idp, protocol string) string {
+	return c.ServiceURL("OS-FEDERATION", "identity_providers", idp, "protocols", protocol, "auth")
+}
@@ -0,0 +1,38 @@
+/*
+Package securitytokens obtains temporary access keys (AK), secret keys (SK)
+and security tokens from the IAM security token API, in exchange for a token.
+
+Example to Obtain Temporary Credentials
+
+	createOpts := securitytokens.CreateOpts{
+		DurationSeconds: 3600,
+		Policy: &securitytokens.Policy{
+			Version: "1.1",
+			Statement: []securitytokens.PolicyStatement{
+				{
+					Effect:   "Allow",
+					Action:   []string{"obs:object:GetObject"},
+					Resource: []string{"obs:*:*:object:my-bucket/*"},
+				},
+			},
+		},
+	}
+
+	credential, err := securitytokens.Create(identityClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	obsClient, err := obs.New(credential.Access, credential.Secret, "https://obs.example.com",
+		obs.WithSecurityToken(credential.SecurityToken))
+
+Example to Use Self-Renewing Temporary Credentials
+
+	creds := securitytokens.NewCredentials(identityClient, createOpts)
+
+	obsClient, err := obs.New("", "", "https://obs.example.com", obs.WithCredentials(creds))
+	if err != nil {
+		panic(err)
+	}
+*/
+package securitytokens
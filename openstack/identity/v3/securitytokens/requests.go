@@ -0,0 +1,120 @@
+package securitytokens
+
+import (
+	"context"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/auth/credentials"
+)
+
+// ProviderName is the Source of credentials retrieved by a Provider.
+const ProviderName = "SecurityTokenProvider"
+
+// CreateOptsBuilder allows extensions to add additional parameters to the
+// Create request.
+type CreateOptsBuilder interface {
+	ToSecurityTokenCreateMap() (map[string]interface{}, error)
+}
+
+// Policy is an inline policy restricting the permissions of temporary
+// credentials further than those of the token they are obtained with.
+type Policy struct {
+	Version   string            `json:"Version"`
+	Statement []PolicyStatement `json:"Statement"`
+}
+
+// PolicyStatement is a statement of a Policy.
+type PolicyStatement struct {
+	Effect    string                         `json:"Effect"`
+	Action    []string                       `json:"Action"`
+	Resource  []string                       `json:"Resource,omitempty"`
+	Condition map[string]map[string][]string `json:"Condition,omitempty"`
+}
+
+// CreateOpts provides options used to obtain temporary credentials.
+type CreateOpts struct {
+	// TokenID is the token to exchange. The token of the client is used
+	// when it is empty.
+	TokenID string `json:"id,omitempty"`
+
+	// DurationSeconds is the validity of the credentials, from 900 to 86400
+	// seconds. The API defaults to 900.
+	DurationSeconds int `json:"duration_seconds,omitempty"`
+
+	// Policy optionally restricts the permissions of the credentials.
+	Policy *Policy `json:"-"`
+}
+
+// ToSecurityTokenCreateMap builds a request body from CreateOpts.
+func (opts CreateOpts) ToSecurityTokenCreateMap() (map[string]interface{}, error) {
+	token, err := golangsdk.BuildRequestBody(opts, "")
+	if err != nil {
+		return nil, err
+	}
+
+	identity := map[string]interface{}{
+		"methods": []string{"token"},
+		"token":   token,
+	}
+	if opts.Policy != nil {
+		identity["policy"] = opts.Policy
+	}
+
+	return map[string]interface{}{
+		"auth": map[string]interface{}{
+			"identity": identity,
+		},
+	}, nil
+}
+
+// Create exchanges a token for temporary credentials. To obtain them with a
+// password, authenticate the provider client with it first.
+func Create(c *golangsdk.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
+	return CreateWithContext(context.Background(), c, opts)
+}
+
+// CreateWithContext is like Create, but binds the request to ctx.
+func CreateWithContext(ctx context.Context, c *golangsdk.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToSecurityTokenCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.PostCtx(ctx, createURL(c), b, &r.Body, &golangsdk.RequestOpts{
+		OkCodes: []int{201},
+	}))
+	return
+}
+
+// Provider is a credentials.Provider of temporary credentials. Wrapped in
+// credentials.Credentials, the credentials are obtained again before they
+// expire, so that clients using them keep working indefinitely.
+type Provider struct {
+	// Client is an identity v3 client, authenticated with the token to
+	// exchange.
+	Client *golangsdk.ServiceClient
+
+	// Opts are the options of every request for credentials.
+	Opts CreateOptsBuilder
+}
+
+// NewCredentials returns self-renewing temporary credentials obtained with
+// client and opts.
+func NewCredentials(client *golangsdk.ServiceClient, opts CreateOptsBuilder) *credentials.Credentials {
+	return credentials.NewCredentials(&Provider{Client: client, Opts: opts})
+}
+
+// Retrieve implements credentials.Provider.
+func (p *Provider) Retrieve(ctx context.Context) (credentials.Value, error) {
+	c, err := CreateWithContext(ctx, p.Client, p.Opts).Extract()
+	if err != nil {
+		return credentials.Value{}, err
+	}
+	return credentials.Value{
+		AccessKey:     c.Access,
+		SecretKey:     c.Secret,
+		SecurityToken: c.SecurityToken,
+		ExpiresAt:     c.ExpiresAt,
+		Source:        ProviderName,
+	}, nil
+}
@@ -0,0 +1,39 @@
+package securitytokens
+
+import (
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+)
+
+// Credential is a set of temporary access key (AK), secret key (SK) and
+// security token.
+type Credential struct {
+	// Access is the temporary access key.
+	Access string `json:"access"`
+
+	// Secret is the temporary secret key.
+	Secret string `json:"secret"`
+
+	// SecurityToken must be sent along with requests signed with the
+	// temporary keys.
+	SecurityToken string `json:"securitytoken"`
+
+	// ExpiresAt is the time the credential expires.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CreateResult is the response from a Create operation. Call its Extract
+// method to interpret it as a Credential.
+type CreateResult struct {
+	golangsdk.Result
+}
+
+// Extract interprets a CreateResult as a Credential.
+func (r CreateResult) Extract() (*Credential, error) {
+	var s struct {
+		Credential *Credential `json:"credential"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Credential, err
+}
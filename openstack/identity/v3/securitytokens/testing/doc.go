@@ -0,0 +1,2 @@
+// securitytokens unit tests
+package testing
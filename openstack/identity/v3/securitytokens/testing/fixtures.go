@@ -0,0 +1,66 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	th "github.com/huaweicloud/golangsdk/testhelper"
+	"github.com/huaweicloud/golangsdk/testhelper/client"
+)
+
+// CreateRequest is the request body of a Create with a duration and policy.
+const CreateRequest = `
+{
+    "auth": {
+        "identity": {
+            "methods": ["token"],
+            "token": {
+                "duration_seconds": 3600
+            },
+            "policy": {
+                "Version": "1.1",
+                "Statement": [
+                    {
+                        "Effect": "Allow",
+                        "Action": ["obs:object:GetObject"],
+                        "Resource": ["obs:*:*:object:my-bucket/*"]
+                    }
+                ]
+            }
+        }
+    }
+}
+`
+
+// CreateOutput is the response to a Create, with a credential expiring at
+// the time given by its argument.
+const CreateOutput = `
+{
+    "credential": {
+        "access": "temporary-access",
+        "secret": "temporary-secret",
+        "securitytoken": "temporary-token",
+        "expires_at": "%s"
+    }
+}
+`
+
+// HandleCreateSuccessfully sets up the test server to respond to Create
+// requests with a credential expiring after expiresIn. It returns a pointer
+// to the number of requests received.
+func HandleCreateSuccessfully(t *testing.T, requestJSON string, expiresIn time.Duration) *int {
+	requests := 0
+	th.Mux.HandleFunc("/v3.0/OS-CREDENTIAL/securitytokens", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestJSONRequest(t, r, requestJSON)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, CreateOutput, time.Now().Add(expiresIn).UTC().Format(time.RFC3339))
+	})
+	return &requests
+}
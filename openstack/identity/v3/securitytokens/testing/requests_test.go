@@ -0,0 +1,85 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/huaweicloud/golangsdk/auth/credentials"
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/securitytokens"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+	"github.com/huaweicloud/golangsdk/testhelper/client"
+)
+
+var createOpts = securitytokens.CreateOpts{
+	DurationSeconds: 3600,
+	Policy: &securitytokens.Policy{
+		Version: "1.1",
+		Statement: []securitytokens.PolicyStatement{
+			{
+				Effect:   "Allow",
+				Action:   []string{"obs:object:GetObject"},
+				Resource: []string{"obs:*:*:object:my-bucket/*"},
+			},
+		},
+	},
+}
+
+func TestCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleCreateSuccessfully(t, CreateRequest, time.Hour)
+
+	actual, err := securitytokens.Create(client.ServiceClient(), createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "temporary-access", actual.Access)
+	th.CheckEquals(t, "temporary-secret", actual.Secret)
+	th.CheckEquals(t, "temporary-token", actual.SecurityToken)
+	if d := time.Until(actual.ExpiresAt); d < 59*time.Minute || d > time.Hour {
+		t.Errorf("unexpected expiry: %s", actual.ExpiresAt)
+	}
+}
+
+func TestCreateWithTokenID(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleCreateSuccessfully(t, `
+		{
+			"auth": {
+				"identity": {
+					"methods": ["token"],
+					"token": {"id": "other-token"}
+				}
+			}
+		}
+	`, time.Hour)
+
+	_, err := securitytokens.Create(client.ServiceClient(), securitytokens.CreateOpts{TokenID: "other-token"}).Extract()
+	th.AssertNoErr(t, err)
+}
+
+func TestNewCredentials(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	requests := HandleCreateSuccessfully(t, CreateRequest, time.Hour)
+
+	creds := securitytokens.NewCredentials(client.ServiceClient(), createOpts)
+
+	v, err := creds.Get(context.Background())
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "temporary-access", v.AccessKey)
+	th.CheckEquals(t, "temporary-token", v.SecurityToken)
+	th.CheckEquals(t, securitytokens.ProviderName, v.Source)
+
+	_, err = creds.Get(context.Background())
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 1, *requests)
+
+	// Credentials expiring within the window are renewed.
+	creds.ExpiryWindow = 2 * time.Hour
+	_, err = creds.Get(context.Background())
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 2, *requests)
+}
+
+var _ credentials.Provider = &securitytokens.Provider{}
@@ -0,0 +1,13 @@
+package securitytokens
+
+import (
+	"strings"
+
+	"github.com/huaweicloud/golangsdk"
+)
+
+// createURL returns the URL of the security token API, which lives under the
+// v3.0 root of the identity service rather than v3.
+func createURL(c *golangsdk.ServiceClient) string {
+	return strings.TrimSuffix(c.Endpoint, "v3/") + "v3.0/OS-CREDENTIAL/securitytokens"
+}
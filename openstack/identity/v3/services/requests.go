@@ -48,9 +48,9 @@ func Create(client *golangsdk.ServiceClient, opts CreateOptsBuilder) (r CreateRe
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(createURL(client), &b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(createURL(client), &b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{201},
-	})
+	}))
 	return
 }
 
@@ -92,7 +92,7 @@ func List(client *golangsdk.ServiceClient, opts ListOptsBuilder) pagination.Page
 
 // Get returns additional information about a service, given its ID.
 func Get(client *golangsdk.ServiceClient, serviceID string) (r GetResult) {
-	_, r.Err = client.Get(serviceURL(client, serviceID), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(serviceURL(client, serviceID), &r.Body, nil))
 	return
 }
 
@@ -139,9 +139,9 @@ func Update(client *golangsdk.ServiceClient, serviceID string, opts UpdateOptsBu
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Patch(updateURL(client, serviceID), &b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Patch(updateURL(client, serviceID), &b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
@@ -149,6 +149,6 @@ func Update(client *golangsdk.ServiceClient, serviceID string, opts UpdateOptsBu
 // It either deletes all associated endpoints, or fails until all endpoints
 // are deleted.
 func Delete(client *golangsdk.ServiceClient, serviceID string) (r DeleteResult) {
-	_, r.Err = client.Delete(serviceURL(client, serviceID), nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Delete(serviceURL(client, serviceID), nil))
 	return
 }
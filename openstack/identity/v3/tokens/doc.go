@@ -104,5 +104,30 @@ Example to Create a Token from a Username and Password with Project Name Scope
 		panic(err)
 	}
 
+Example to Create a Token From a Password and a TOTP Passcode
+
+	authOptions := tokens.AuthOptions{
+		UserID:   "user_id",
+		Password: "password",
+		Passcode: "123456",
+	}
+
+	token, err := tokens.Create(identityClient, authOptions).ExtractToken()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Create a Token From an Application Credential
+
+	authOptions := tokens.AuthOptions{
+		ApplicationCredentialID:     "application_credential_id",
+		ApplicationCredentialSecret: "secret",
+	}
+
+	token, err := tokens.Create(identityClient, authOptions).ExtractToken()
+	if err != nil {
+		panic(err)
+	}
+
 */
 package tokens
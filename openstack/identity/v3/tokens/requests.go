@@ -1,6 +1,10 @@
 package tokens
 
-import "github.com/huaweicloud/golangsdk"
+import (
+	"context"
+
+	"github.com/huaweicloud/golangsdk"
+)
 
 // Scope allows a created token to be limited to a specific domain or project.
 type Scope struct {
@@ -20,6 +24,13 @@ type AuthOptionsBuilder interface {
 	CanReauth() bool
 }
 
+// SourceAuthOptionsBuilder is implemented by extensions whose token request
+// is authenticated with the token of other credentials, such as an IAM
+// agency. Create issues a token for the source options first.
+type SourceAuthOptionsBuilder interface {
+	SourceAuthOptions() AuthOptionsBuilder
+}
+
 // AuthOptions represents options for authenticating a user.
 type AuthOptions struct {
 	// IdentityEndpoint specifies the HTTP endpoint that is required to work with
@@ -52,6 +63,17 @@ type AuthOptions struct {
 	// authentication token ID.
 	TokenID string `json:"-"`
 
+	// Passcode is the current TOTP passcode of a user protected by
+	// multi-factor authentication. It is sent with the Password, or alone.
+	Passcode string `json:"-"`
+
+	// ApplicationCredentialID or ApplicationCredentialName, together with
+	// ApplicationCredentialSecret, authenticate with an application
+	// credential. A credential given by name also needs its user.
+	ApplicationCredentialID     string `json:"-"`
+	ApplicationCredentialName   string `json:"-"`
+	ApplicationCredentialSecret string `json:"-"`
+
 	Scope Scope `json:"-"`
 }
 
@@ -65,6 +87,11 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]interface{}) (map[s
 		DomainName:  opts.DomainName,
 		AllowReauth: opts.AllowReauth,
 		TokenID:     opts.TokenID,
+		Passcode:    opts.Passcode,
+
+		ApplicationCredentialID:     opts.ApplicationCredentialID,
+		ApplicationCredentialName:   opts.ApplicationCredentialName,
+		ApplicationCredentialSecret: opts.ApplicationCredentialSecret,
 	}
 
 	return golangsdkAuthOpts.ToTokenV3CreateMap(scope)
@@ -153,6 +180,11 @@ func subjectTokenHeaders(c *golangsdk.ServiceClient, subjectToken string) map[st
 // Create authenticates and either generates a new token, or changes the Scope
 // of an existing token.
 func Create(c *golangsdk.ServiceClient, opts AuthOptionsBuilder) (r CreateResult) {
+	return CreateWithContext(context.Background(), c, opts)
+}
+
+// CreateWithContext is like Create, but binds the token request to ctx.
+func CreateWithContext(ctx context.Context, c *golangsdk.ServiceClient, opts AuthOptionsBuilder) (r CreateResult) {
 	scope, err := opts.ToTokenV3ScopeMap()
 	if err != nil {
 		r.Err = err
@@ -165,26 +197,28 @@ func Create(c *golangsdk.ServiceClient, opts AuthOptionsBuilder) (r CreateResult
 		return
 	}
 
-	resp, err := c.Post(tokenURL(c), b, &r.Body, &golangsdk.RequestOpts{
-		MoreHeaders: map[string]string{"X-Auth-Token": ""},
-	})
-	r.Err = err
-	if resp != nil {
-		r.Header = resp.Header
+	headers := map[string]string{"X-Auth-Token": ""}
+	if source, ok := opts.(SourceAuthOptionsBuilder); ok {
+		sourceToken, err := CreateWithContext(ctx, c, source.SourceAuthOptions()).ExtractToken()
+		if err != nil {
+			r.Err = err
+			return
+		}
+		headers["X-Auth-Token"] = sourceToken.ID
 	}
+
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.PostCtx(ctx, tokenURL(c), b, &r.Body, &golangsdk.RequestOpts{
+		MoreHeaders: headers,
+	}))
 	return
 }
 
 // Get validates and retrieves information about another token.
 func Get(c *golangsdk.ServiceClient, token string) (r GetResult) {
-	resp, err := c.Get(tokenURL(c), &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Get(tokenURL(c), &r.Body, &golangsdk.RequestOpts{
 		MoreHeaders: subjectTokenHeaders(c, token),
 		OkCodes:     []int{200, 203},
-	})
-	if resp != nil {
-		r.Err = err
-		r.Header = resp.Header
-	}
+	}))
 	return
 }
 
@@ -203,8 +237,8 @@ func Validate(c *golangsdk.ServiceClient, token string) (bool, error) {
 
 // Revoke immediately makes specified token invalid.
 func Revoke(c *golangsdk.ServiceClient, token string) (r RevokeResult) {
-	_, r.Err = c.Delete(tokenURL(c), &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Delete(tokenURL(c), &golangsdk.RequestOpts{
 		MoreHeaders: subjectTokenHeaders(c, token),
-	})
+	}))
 	return
 }
@@ -144,6 +144,93 @@ func TestCreateTokenID(t *testing.T) {
 	`)
 }
 
+func TestCreateUserIDPasswordAndPasscode(t *testing.T) {
+	authTokenPost(t, tokens.AuthOptions{UserID: "me", Password: "squirrel!", Passcode: "123456"}, nil, `
+		{
+			"auth": {
+				"identity": {
+					"methods": ["password", "totp"],
+					"password": {
+						"user": { "id": "me", "password": "squirrel!" }
+					},
+					"totp": {
+						"user": { "id": "me", "passcode": "123456" }
+					}
+				}
+			}
+		}
+	`)
+}
+
+func TestCreateUsernameDomainNamePasscode(t *testing.T) {
+	authTokenPost(t, tokens.AuthOptions{Username: "fakey", Passcode: "123456", DomainName: "default"}, nil, `
+		{
+			"auth": {
+				"identity": {
+					"methods": ["totp"],
+					"totp": {
+						"user": {
+							"domain": {
+								"name": "default"
+							},
+							"name": "fakey",
+							"passcode": "123456"
+						}
+					}
+				}
+			}
+		}
+	`)
+}
+
+func TestCreateApplicationCredentialID(t *testing.T) {
+	options := tokens.AuthOptions{
+		ApplicationCredentialID:     "aa8f8a91",
+		ApplicationCredentialSecret: "ThisIsASecret",
+	}
+	authTokenPost(t, options, nil, `
+		{
+			"auth": {
+				"identity": {
+					"methods": ["application_credential"],
+					"application_credential": {
+						"id": "aa8f8a91",
+						"secret": "ThisIsASecret"
+					}
+				}
+			}
+		}
+	`)
+}
+
+func TestCreateApplicationCredentialNameAndUsername(t *testing.T) {
+	options := tokens.AuthOptions{
+		ApplicationCredentialName:   "monitoring",
+		ApplicationCredentialSecret: "ThisIsASecret",
+		Username:                    "fakey",
+		DomainID:                    "abc123",
+	}
+	authTokenPost(t, options, nil, `
+		{
+			"auth": {
+				"identity": {
+					"methods": ["application_credential"],
+					"application_credential": {
+						"name": "monitoring",
+						"user": {
+							"domain": {
+								"id": "abc123"
+							},
+							"name": "fakey"
+						},
+						"secret": "ThisIsASecret"
+					}
+				}
+			}
+		}
+	`)
+}
+
 func TestCreateProjectIDScope(t *testing.T) {
 	options := tokens.AuthOptions{UserID: "fenris", Password: "g0t0h311"}
 	scope := &tokens.Scope{ProjectID: "123456"}
@@ -376,6 +463,80 @@ func TestCreateFailureUserIDDomainName(t *testing.T) {
 	authTokenPostErr(t, options, nil, false, golangsdk.ErrDomainNameWithUserID{})
 }
 
+func TestCreateFailurePasscodeWithToken(t *testing.T) {
+	options := tokens.AuthOptions{TokenID: "12345", Passcode: "123456"}
+	authTokenPostErr(t, options, nil, false, golangsdk.ErrPasscodeWithToken{})
+}
+
+func TestCreateFailureAppCredIDAndName(t *testing.T) {
+	options := tokens.AuthOptions{
+		ApplicationCredentialID:     "aa8f8a91",
+		ApplicationCredentialName:   "monitoring",
+		ApplicationCredentialSecret: "ThisIsASecret",
+	}
+	authTokenPostErr(t, options, nil, false, golangsdk.ErrAppCredIDOrAppCredName{})
+}
+
+func TestCreateFailureAppCredMissingSecret(t *testing.T) {
+	options := tokens.AuthOptions{ApplicationCredentialID: "aa8f8a91"}
+	authTokenPostErr(t, options, nil, false, golangsdk.ErrAppCredMissingSecret{})
+}
+
+func TestCreateFailureAppCredNameWithoutUser(t *testing.T) {
+	options := tokens.AuthOptions{
+		ApplicationCredentialName:   "monitoring",
+		ApplicationCredentialSecret: "ThisIsASecret",
+	}
+	authTokenPostErr(t, options, nil, false, golangsdk.ErrAppCredNameWithoutUser{})
+}
+
+func TestCreateFailureAppCredNameUserWithoutDomain(t *testing.T) {
+	options := tokens.AuthOptions{
+		ApplicationCredentialName:   "monitoring",
+		ApplicationCredentialSecret: "ThisIsASecret",
+		Username:                    "fakey",
+	}
+	authTokenPostErr(t, options, nil, false, golangsdk.ErrDomainIDOrDomainName{})
+}
+
+func TestCreateFailureAppCredNameUserIDWithDomain(t *testing.T) {
+	options := tokens.AuthOptions{
+		ApplicationCredentialName:   "monitoring",
+		ApplicationCredentialSecret: "ThisIsASecret",
+		UserID:                      "me",
+		DomainName:                  "default",
+	}
+	authTokenPostErr(t, options, nil, false, golangsdk.ErrDomainNameWithUserID{})
+}
+
+func TestCreateFailureAppCredWithPassword(t *testing.T) {
+	options := tokens.AuthOptions{
+		ApplicationCredentialID:     "aa8f8a91",
+		ApplicationCredentialSecret: "ThisIsASecret",
+		UserID:                      "me",
+		Password:                    "squirrel!",
+	}
+	authTokenPostErr(t, options, nil, false, golangsdk.ErrPasswordWithAppCred{})
+}
+
+func TestCreateFailureAppCredWithTokenID(t *testing.T) {
+	options := tokens.AuthOptions{
+		ApplicationCredentialID:     "aa8f8a91",
+		ApplicationCredentialSecret: "ThisIsASecret",
+		TokenID:                     "12345",
+	}
+	authTokenPostErr(t, options, nil, false, golangsdk.ErrTokenIDWithAppCred{})
+}
+
+func TestCreateFailureAppCredWithPasscode(t *testing.T) {
+	options := tokens.AuthOptions{
+		ApplicationCredentialID:     "aa8f8a91",
+		ApplicationCredentialSecret: "ThisIsASecret",
+		Passcode:                    "123456",
+	}
+	authTokenPostErr(t, options, nil, false, golangsdk.ErrPasscodeWithAppCred{})
+}
+
 func TestCreateFailureScopeProjectNameAlone(t *testing.T) {
 	options := tokens.AuthOptions{UserID: "myself", Password: "swordfish"}
 	scope := &tokens.Scope{ProjectName: "notenough"}
@@ -1,8 +1,6 @@
 package cluster
 
 import (
-	"log"
-
 	"github.com/huaweicloud/golangsdk"
 )
 
@@ -68,23 +66,23 @@ func Create(c *golangsdk.ServiceClient, opts CreateOptsBuilder) (r CreateResult)
 		r.Err = err
 		return
 	}
-	log.Printf("[DEBUG] create url:%q, body=%#v", createURL(c), b)
+	c.LogDebug("create MRS cluster", map[string]interface{}{"url": createURL(c), "body": golangsdk.RedactBody(b)})
 	reqOpt := &golangsdk.RequestOpts{OkCodes: []int{200}}
-	_, r.Err = c.Post(createURL(c), b, &r.Body, reqOpt)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Post(createURL(c), b, &r.Body, reqOpt))
 	return
 }
 
 func Get(c *golangsdk.ServiceClient, id string) (r GetResult) {
-	_, r.Err = c.Get(getURL(c, id), &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Get(getURL(c, id), &r.Body, &golangsdk.RequestOpts{
 		OkCodes:     []int{200},
 		MoreHeaders: RequestOpts.MoreHeaders, JSONBody: nil,
-	})
+	}))
 	return
 }
 
 func Delete(c *golangsdk.ServiceClient, id string) (r DeleteResult) {
 	reqOpt := &golangsdk.RequestOpts{OkCodes: []int{204},
 		MoreHeaders: RequestOpts.MoreHeaders}
-	_, r.Err = c.Delete(deleteURL(c, id), reqOpt)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Delete(deleteURL(c, id), reqOpt))
 	return
 }
@@ -1,8 +1,6 @@
 package job
 
 import (
-	"log"
-
 	"github.com/huaweicloud/golangsdk"
 )
 
@@ -38,23 +36,23 @@ func Create(c *golangsdk.ServiceClient, opts CreateOptsBuilder) (r CreateResult)
 		r.Err = err
 		return
 	}
-	log.Printf("[DEBUG] create url:%q, body=%#v", createURL(c), b)
+	c.LogDebug("create MRS job", map[string]interface{}{"url": createURL(c), "body": golangsdk.RedactBody(b)})
 	reqOpt := &golangsdk.RequestOpts{OkCodes: []int{200},
 		MoreHeaders: RequestOpts.MoreHeaders}
-	_, r.Err = c.Post(createURL(c), b, &r.Body, reqOpt)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Post(createURL(c), b, &r.Body, reqOpt))
 	return
 }
 
 func Get(c *golangsdk.ServiceClient, id string) (r GetResult) {
 	reqOpt := &golangsdk.RequestOpts{OkCodes: []int{200},
 		MoreHeaders: RequestOpts.MoreHeaders}
-	_, r.Err = c.Get(getURL(c, id), &r.Body, reqOpt)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Get(getURL(c, id), &r.Body, reqOpt))
 	return
 }
 
 func Delete(c *golangsdk.ServiceClient, id string) (r DeleteResult) {
 	reqOpt := &golangsdk.RequestOpts{OkCodes: []int{204},
 		MoreHeaders: RequestOpts.MoreHeaders}
-	_, r.Err = c.Delete(deleteURL(c, id), reqOpt)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Delete(deleteURL(c, id), reqOpt))
 	return
 }
@@ -1,6 +1,8 @@
 package eips
 
 import (
+	"time"
+
 	"github.com/huaweicloud/golangsdk"
 )
 
@@ -39,21 +41,21 @@ func Apply(client *golangsdk.ServiceClient, opts ApplyOptsBuilder) (r ApplyResul
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(rootURL(client), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(rootURL(client), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
 //Get is a method by which can get the detailed information of public ip
 func Get(client *golangsdk.ServiceClient, id string) (r GetResult) {
-	_, r.Err = client.Get(resourceURL(client, id), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(resourceURL(client, id), &r.Body, nil))
 	return
 }
 
 //Delete is a method by which can be able to delete a private ip
 func Delete(client *golangsdk.ServiceClient, id string) (r DeleteResult) {
-	_, r.Err = client.Delete(resourceURL(client, id), nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Delete(resourceURL(client, id), nil))
 	return
 }
 
@@ -79,8 +81,25 @@ func Update(client *golangsdk.ServiceClient, id string, opts UpdateOptsBuilder)
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Put(resourceURL(client, id), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Put(resourceURL(client, id), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
+
+// WaitForStatus polls an elastic IP until it reaches status, such as "DOWN"
+// once created or "ACTIVE" once bound, or gives up after timeout. The ERROR
+// and BIND_ERROR statuses end the wait early.
+func WaitForStatus(client *golangsdk.ServiceClient, id, status string, timeout time.Duration) (PublicIp, error) {
+	r, err := golangsdk.WaitForStatus(func() (interface{}, string, error) {
+		ip, err := Get(client, id).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+		return ip, ip.Status, nil
+	}, []string{status}, nil, []string{"ERROR", "BIND_ERROR"}, timeout)
+	if err != nil {
+		return PublicIp{}, err
+	}
+	return r.(PublicIp), nil
+}
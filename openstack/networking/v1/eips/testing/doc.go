@@ -0,0 +1,2 @@
+// eips unit tests
+package testing
@@ -0,0 +1,58 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	fake "github.com/huaweicloud/golangsdk/openstack/networking/v1/common"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v1/eips"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+// handleGetPublicIp serves the elastic IP in each of statuses in turn,
+// staying in the last one.
+func handleGetPublicIp(t *testing.T, statuses ...string) {
+	th.Mux.HandleFunc("/v1/85636478b0bd8e67e89469c7749d4127/publicips/2ec9b78d-9368-46f3-8f29-d1a95622a568", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		status := statuses[0]
+		if len(statuses) > 1 {
+			statuses = statuses[1:]
+		}
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"publicip": {"id": "2ec9b78d-9368-46f3-8f29-d1a95622a568", "status": "%s"}}`, status)
+	})
+}
+
+func TestWaitForStatus(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleGetPublicIp(t, "PENDING_CREATE", "DOWN")
+
+	ip, err := eips.WaitForStatus(fake.ServiceClient(), "2ec9b78d-9368-46f3-8f29-d1a95622a568", "DOWN", 10*time.Second)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "2ec9b78d-9368-46f3-8f29-d1a95622a568", ip.ID)
+	th.CheckEquals(t, "DOWN", ip.Status)
+}
+
+func TestWaitForStatusFailed(t *testing.T) {
+	for _, status := range []string{"ERROR", "BIND_ERROR"} {
+		th.SetupHTTP()
+		handleGetPublicIp(t, status)
+
+		_, err := eips.WaitForStatus(fake.ServiceClient(), "2ec9b78d-9368-46f3-8f29-d1a95622a568", "DOWN", 10*time.Second)
+		th.TeardownHTTP()
+
+		e, ok := err.(golangsdk.ErrUnexpectedStatus)
+		if !ok {
+			t.Fatalf("expected ErrUnexpectedStatus for %s, got %v", status, err)
+		}
+		th.CheckEquals(t, status, e.Status)
+		th.CheckEquals(t, status, e.Resource.(eips.PublicIp).Status)
+	}
+}
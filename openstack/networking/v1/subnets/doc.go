@@ -3,12 +3,24 @@ Package Subnets enables management and retrieval of Subnets
 
 Example to List Vpcs
 
-	listOpts := subnets.ListOpts{}
-	allSubnets, err := subnets.List(subnetClient, listOpts)
+	listOpts := subnets.ListOpts{
+		VPC_ID: "3b9740a0-b44d-48f0-84ee-42eb166e54f7",
+	}
+	allPages, err := subnets.List(subnetClient, listOpts).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allSubnets, err := subnets.ExtractSubnets(allPages)
 	if err != nil {
 		panic(err)
 	}
 
+	// The API cannot filter on the name.
+	allSubnets = subnets.FilterSubnets(allSubnets, func(s subnets.Subnet) bool {
+		return s.Name == "test_subnets"
+	})
+
 	for _, subnet := range allSubnets {
 		fmt.Printf("%+v\n", subnet)
 	}
@@ -1,129 +1,73 @@
 package subnets
 
 import (
-	"reflect"
-
 	"github.com/huaweicloud/golangsdk"
 	"github.com/huaweicloud/golangsdk/pagination"
 )
 
-// ListOpts allows the filtering and sorting of paginated collections through
-// the API. Filtering is achieved by passing in struct field values that map to
-// the floating IP attributes you want to see returned. SortKey allows you to
-// sort by a particular network attribute. SortDir sets the direction, and is
-// either `asc' or `desc'. Marker and Limit are used for pagination.
+// ListOptsBuilder allows extensions to add additional parameters to the
+// List request.
+type ListOptsBuilder interface {
+	ToSubnetListQuery() (string, error)
+}
 
+// ListOpts allows the filtering and paging of subnets through the API. Use
+// FilterSubnets for the attributes the API cannot filter on, such as the
+// name or the CIDR. Marker and Limit are used for pagination.
 type ListOpts struct {
-	// ID is the unique identifier for the subnet.
-	ID string `json:"id"`
-
-	// Name is the human readable name for the subnet. It does not have to be
-	// unique.
-	Name string `json:"name"`
-
-	//Specifies the network segment on which the subnet resides.
-	CIDR string `json:"cidr"`
-
-	// Status indicates whether or not a subnet is currently operational.
-	Status string `json:"status"`
-
-	//Specifies the gateway of the subnet.
-	GatewayIP string `json:"gateway_ip"`
-
-	//Specifies the IP address of DNS server 1 on the subnet.
-	PRIMARY_DNS string `json:"primary_dns"`
+	//Specifies the ID of the VPC to which the subnet belongs.
+	VPC_ID string `q:"vpc_id"`
 
-	//Specifies the IP address of DNS server 2 on the subnet.
-	SECONDARY_DNS string `json:"secondary_dns"`
+	// Marker is the ID of the last subnet of the previous page.
+	Marker string `q:"marker"`
 
-	//Identifies the availability zone (AZ) to which the subnet belongs.
-	AvailabilityZone string `json:"availability_zone"`
+	// Limit is the number of subnets returned in a page.
+	Limit int `q:"limit"`
+}
 
-	//Specifies the ID of the VPC to which the subnet belongs.
-	VPC_ID string `json:"vpc_id"`
+// ToSubnetListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToSubnetListQuery() (string, error) {
+	q, err := golangsdk.BuildQueryString(opts)
+	return q.String(), err
 }
 
-// List returns collection of
-// subnets. It accepts a ListOpts struct, which allows you to filter and sort
-// the returned collection for greater efficiency.
+// List returns a Pager which allows you to iterate over a collection of
+// subnets. It accepts a ListOpts struct, which allows you to filter the
+// returned collection for greater efficiency.
 //
 // Default policy settings return only those subnets that are owned by the
 // tenant who submits the request, unless an admin user submits the request.
-
-func List(c *golangsdk.ServiceClient, opts ListOpts) ([]Subnet, error) {
-	u := rootURL(c)
-	pages, err := pagination.NewPager(c, u, func(r pagination.PageResult) pagination.Page {
-		return SubnetPage{pagination.LinkedPageBase{PageResult: r}}
-	}).AllPages()
-
-	allSubnets, err := ExtractSubnets(pages)
-	if err != nil {
-		return nil, err
+func List(c *golangsdk.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := rootURL(c)
+	if opts != nil {
+		query, err := opts.ToSubnetListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
 	}
 
-	return FilterSubnets(allSubnets, opts)
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		p := SubnetPage{pagination.MarkerPageBase{PageResult: r}}
+		p.MarkerPageBase.Owner = p
+		return p
+	})
 }
 
-func FilterSubnets(subnets []Subnet, opts ListOpts) ([]Subnet, error) {
-
+// FilterSubnets returns the subnets for which keep returns true. It is a
+// client-side fallback for the attributes List cannot filter on:
+//
+//	mine := subnets.FilterSubnets(allSubnets, func(s subnets.Subnet) bool {
+//		return s.CIDR == "192.168.0.0/24"
+//	})
+func FilterSubnets(subnets []Subnet, keep func(Subnet) bool) []Subnet {
 	var refinedSubnets []Subnet
-	var matched bool
-	m := map[string]interface{}{}
-
-	if opts.ID != "" {
-		m["ID"] = opts.ID
-	}
-	if opts.Name != "" {
-		m["Name"] = opts.Name
-	}
-	if opts.CIDR != "" {
-		m["CIDR"] = opts.CIDR
-	}
-	if opts.Status != "" {
-		m["Status"] = opts.Status
-	}
-	if opts.GatewayIP != "" {
-		m["GatewayIP"] = opts.GatewayIP
-	}
-	if opts.PRIMARY_DNS != "" {
-		m["PRIMARY_DNS"] = opts.PRIMARY_DNS
-	}
-	if opts.SECONDARY_DNS != "" {
-		m["SECONDARY_DNS"] = opts.SECONDARY_DNS
-	}
-	if opts.AvailabilityZone != "" {
-		m["AvailabilityZone"] = opts.AvailabilityZone
-	}
-	if opts.VPC_ID != "" {
-		m["VPC_ID"] = opts.VPC_ID
-	}
-
-	if len(m) > 0 && len(subnets) > 0 {
-		for _, subnet := range subnets {
-			matched = true
-
-			for key, value := range m {
-				if sVal := getStructField(&subnet, key); !(sVal == value) {
-					matched = false
-				}
-			}
-
-			if matched {
-				refinedSubnets = append(refinedSubnets, subnet)
-			}
+	for _, subnet := range subnets {
+		if keep(subnet) {
+			refinedSubnets = append(refinedSubnets, subnet)
 		}
-
-	} else {
-		refinedSubnets = subnets
 	}
-
-	return refinedSubnets, nil
-}
-
-func getStructField(v *Subnet, field string) string {
-	r := reflect.ValueOf(v)
-	f := reflect.Indirect(r).FieldByName(field)
-	return string(f.String())
+	return refinedSubnets
 }
 
 // CreateOptsBuilder allows extensions to add additional parameters to the
@@ -162,13 +106,13 @@ func Create(c *golangsdk.ServiceClient, opts CreateOptsBuilder) (r CreateResult)
 		return
 	}
 	reqOpt := &golangsdk.RequestOpts{OkCodes: []int{200}}
-	_, r.Err = c.Post(rootURL(c), b, &r.Body, reqOpt)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Post(rootURL(c), b, &r.Body, reqOpt))
 	return
 }
 
 // Get retrieves a particular subnets based on its unique ID.
 func Get(c *golangsdk.ServiceClient, id string) (r GetResult) {
-	_, r.Err = c.Get(resourceURL(c, id), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Get(resourceURL(c, id), &r.Body, nil))
 	return
 }
 
@@ -201,14 +145,14 @@ func Update(c *golangsdk.ServiceClient, vpcid string, id string, opts UpdateOpts
 		r.Err = err
 		return
 	}
-	_, r.Err = c.Put(updateURL(c, vpcid, id), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Put(updateURL(c, vpcid, id), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
 // Delete will permanently delete a particular subnets based on its unique ID.
 func Delete(c *golangsdk.ServiceClient, vpcid string, id string) (r DeleteResult) {
-	_, r.Err = c.Delete(updateURL(c, vpcid, id), nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Delete(updateURL(c, vpcid, id), nil))
 	return
 }
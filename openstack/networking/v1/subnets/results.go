@@ -44,21 +44,17 @@ type Subnet struct {
 // SubnetPage is the page returned by a pager when traversing over a
 // collection of subnets.
 type SubnetPage struct {
-	pagination.LinkedPageBase
+	pagination.MarkerPageBase
 }
 
-// NextPageURL is invoked when a paginated collection of subnets has reached
-// the end of a page and the pager seeks to traverse over a new one. In order
-// to do this, it needs to construct the next page's URL.
-func (r SubnetPage) NextPageURL() (string, error) {
-	var s struct {
-		Links []golangsdk.Link `json:"subnets_links"`
-	}
-	err := r.ExtractInto(&s)
-	if err != nil {
+// LastMarker returns the ID of the last subnet on the page, which the API
+// takes as the marker of the next page.
+func (r SubnetPage) LastMarker() (string, error) {
+	items, err := ExtractSubnets(r)
+	if err != nil || len(items) == 0 {
 		return "", err
 	}
-	return golangsdk.ExtractNextURL(s.Links)
+	return items[len(items)-1].ID, nil
 }
 
 // IsEmpty checks whether a SubnetPage struct is empty.
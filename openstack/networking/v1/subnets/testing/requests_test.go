@@ -21,6 +21,14 @@ func TestListSubnet(t *testing.T) {
 		w.Header().Add("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 
+		r.ParseForm()
+		if r.Form.Get("marker") != "" {
+			th.TestFormValues(t, r, map[string]string{"vpc_id": "58c24204-170e-4ff0-9b42-c53cdea9239a", "marker": "134ca339-24dc-44f5-ae6a-cf0404216ed2"})
+			fmt.Fprintf(w, `{"subnets": []}`)
+			return
+		}
+		th.TestFormValues(t, r, map[string]string{"vpc_id": "58c24204-170e-4ff0-9b42-c53cdea9239a"})
+
 		fmt.Fprintf(w, `
 {
     "subnets": [
@@ -60,10 +68,11 @@ func TestListSubnet(t *testing.T) {
 		`)
 	})
 
-	actual, err := subnets.List(fake.ServiceClient(), subnets.ListOpts{})
-	if err != nil {
-		t.Errorf("Failed to extract subnets: %v", err)
-	}
+	listOpts := subnets.ListOpts{VPC_ID: "58c24204-170e-4ff0-9b42-c53cdea9239a"}
+	allPages, err := subnets.List(fake.ServiceClient(), listOpts).AllPages()
+	th.AssertNoErr(t, err)
+	actual, err := subnets.ExtractSubnets(allPages)
+	th.AssertNoErr(t, err)
 
 	expected := []subnets.Subnet{
 		{
@@ -94,6 +103,11 @@ func TestListSubnet(t *testing.T) {
 		},
 	}
 	th.AssertDeepEquals(t, expected, actual)
+
+	none := subnets.FilterSubnets(actual, func(s subnets.Subnet) bool {
+		return s.CIDR == "10.0.0.0/24"
+	})
+	th.AssertEquals(t, 0, len(none))
 }
 
 func TestGetSubnet(t *testing.T) {
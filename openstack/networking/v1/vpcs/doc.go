@@ -5,11 +5,21 @@ VPC service.
 Example to List Vpcs
 
 	listOpts := vpcs.ListOpts{}
-	allVpcs, err := vpcs.List(vpcClient, listOpts)
+	allPages, err := vpcs.List(vpcClient, listOpts).AllPages()
 	if err != nil {
 		panic(err)
 	}
 
+	allVpcs, err := vpcs.ExtractVpcs(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	// The API cannot filter on the name.
+	allVpcs = vpcs.FilterVPCs(allVpcs, func(v vpcs.Vpc) bool {
+		return v.Name == "vpc_1"
+	})
+
 	for _, vpc := range allVpcs {
 		fmt.Printf("%+v\n", vpc)
 	}
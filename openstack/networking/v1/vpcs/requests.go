@@ -1,98 +1,75 @@
 package vpcs
 
 import (
-	"reflect"
+	"time"
 
 	"github.com/huaweicloud/golangsdk"
 	"github.com/huaweicloud/golangsdk/pagination"
 )
 
-// ListOpts allows the filtering and sorting of paginated collections through
-// the API. Filtering is achieved by passing in struct field values that map to
-// the floating IP attributes you want to see returned. SortKey allows you to
-// sort by a particular network attribute. SortDir sets the direction, and is
-// either `asc' or `desc'. Marker and Limit are used for pagination.
+// ListOptsBuilder allows extensions to add additional parameters to the
+// List request.
+type ListOptsBuilder interface {
+	ToVpcListQuery() (string, error)
+}
 
+// ListOpts allows the filtering and paging of vpcs through the API. Use
+// FilterVPCs for the attributes the API cannot filter on, such as the name.
+// Marker and Limit are used for pagination.
 type ListOpts struct {
 	// ID is the unique identifier for the vpc.
-	ID string `json:"id"`
+	ID string `q:"id"`
 
-	// Name is the human readable name for the vpc. It does not have to be
-	// unique.
-	Name string `json:"name"`
+	// Marker is the ID of the last vpc of the previous page.
+	Marker string `q:"marker"`
 
-	//Specifies the range of available subnets in the VPC.
-	CIDR string `json:"cidr"`
+	// Limit is the number of vpcs returned in a page.
+	Limit int `q:"limit"`
+}
 
-	// Status indicates whether or not a vpc is currently operational.
-	Status string `json:"status"`
+// ToVpcListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToVpcListQuery() (string, error) {
+	q, err := golangsdk.BuildQueryString(opts)
+	return q.String(), err
 }
 
-// List returns collection of
-// vpcs. It accepts a ListOpts struct, which allows you to filter and sort
-// the returned collection for greater efficiency.
+// List returns a Pager which allows you to iterate over a collection of
+// vpcs. It accepts a ListOpts struct, which allows you to filter the returned
+// collection for greater efficiency.
 //
 // Default policy settings return only those vpcs that are owned by the
 // tenant who submits the request, unless an admin user submits the request.
-func List(c *golangsdk.ServiceClient, opts ListOpts) ([]Vpc, error) {
-	u := rootURL(c)
-	pages, err := pagination.NewPager(c, u, func(r pagination.PageResult) pagination.Page {
-		return VpcPage{pagination.LinkedPageBase{PageResult: r}}
-	}).AllPages()
-
-	allVpcs, err := ExtractVpcs(pages)
-	if err != nil {
-		return nil, err
+func List(c *golangsdk.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := rootURL(c)
+	if opts != nil {
+		query, err := opts.ToVpcListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
 	}
 
-	return FilterVPCs(allVpcs, opts)
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		p := VpcPage{pagination.MarkerPageBase{PageResult: r}}
+		p.MarkerPageBase.Owner = p
+		return p
+	})
 }
 
-func FilterVPCs(vpcs []Vpc, opts ListOpts) ([]Vpc, error) {
-
+// FilterVPCs returns the vpcs for which keep returns true. It is a
+// client-side fallback for the attributes List cannot filter on:
+//
+//	mine := vpcs.FilterVPCs(allVpcs, func(v vpcs.Vpc) bool {
+//		return v.Name == "vpc_1"
+//	})
+func FilterVPCs(vpcs []Vpc, keep func(Vpc) bool) []Vpc {
 	var refinedVPCs []Vpc
-	var matched bool
-	m := map[string]interface{}{}
-
-	if opts.ID != "" {
-		m["ID"] = opts.ID
-	}
-	if opts.Name != "" {
-		m["Name"] = opts.Name
-	}
-	if opts.Status != "" {
-		m["Status"] = opts.Status
-	}
-	if opts.CIDR != "" {
-		m["CIDR"] = opts.CIDR
-	}
-
-	if len(m) > 0 && len(vpcs) > 0 {
-		for _, vpc := range vpcs {
-			matched = true
-
-			for key, value := range m {
-				if sVal := getStructField(&vpc, key); !(sVal == value) {
-					matched = false
-				}
-			}
-
-			if matched {
-				refinedVPCs = append(refinedVPCs, vpc)
-			}
+	for _, vpc := range vpcs {
+		if keep(vpc) {
+			refinedVPCs = append(refinedVPCs, vpc)
 		}
-
-	} else {
-		refinedVPCs = vpcs
 	}
-
-	return refinedVPCs, nil
-}
-
-func getStructField(v *Vpc, field string) string {
-	r := reflect.ValueOf(v)
-	f := reflect.Indirect(r).FieldByName(field)
-	return string(f.String())
+	return refinedVPCs
 }
 
 // CreateOptsBuilder allows extensions to add additional parameters to the
@@ -128,13 +105,13 @@ func Create(c *golangsdk.ServiceClient, opts CreateOptsBuilder) (r CreateResult)
 		return
 	}
 	reqOpt := &golangsdk.RequestOpts{OkCodes: []int{200}}
-	_, r.Err = c.Post(rootURL(c), b, &r.Body, reqOpt)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Post(rootURL(c), b, &r.Body, reqOpt))
 	return
 }
 
 // Get retrieves a particular vpc based on its unique ID.
 func Get(c *golangsdk.ServiceClient, id string) (r GetResult) {
-	_, r.Err = c.Get(resourceURL(c, id), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Get(resourceURL(c, id), &r.Body, nil))
 	return
 }
 
@@ -166,14 +143,30 @@ func Update(c *golangsdk.ServiceClient, id string, opts UpdateOptsBuilder) (r Up
 		r.Err = err
 		return
 	}
-	_, r.Err = c.Put(resourceURL(c, id), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Put(resourceURL(c, id), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
 // Delete will permanently delete a particular vpc based on its unique ID.
 func Delete(c *golangsdk.ServiceClient, id string) (r DeleteResult) {
-	_, r.Err = c.Delete(resourceURL(c, id), nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Delete(resourceURL(c, id), nil))
 	return
 }
+
+// WaitForStatus polls a VPC until it reaches status, usually "OK", or gives
+// up after timeout. A VPC in the ERROR status ends the wait early.
+func WaitForStatus(c *golangsdk.ServiceClient, id, status string, timeout time.Duration) (*Vpc, error) {
+	r, err := golangsdk.WaitForStatus(func() (interface{}, string, error) {
+		vpc, err := Get(c, id).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+		return vpc, vpc.Status, nil
+	}, []string{status}, nil, []string{"ERROR"}, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return r.(*Vpc), nil
+}
@@ -43,21 +43,17 @@ type Vpc struct {
 // VpcPage is the page returned by a pager when traversing over a
 // collection of vpcs.
 type VpcPage struct {
-	pagination.LinkedPageBase
+	pagination.MarkerPageBase
 }
 
-// NextPageURL is invoked when a paginated collection of vpcs has reached
-// the end of a page and the pager seeks to traverse over a new one. In order
-// to do this, it needs to construct the next page's URL.
-func (r VpcPage) NextPageURL() (string, error) {
-	var s struct {
-		Links []golangsdk.Link `json:"vpcs_links"`
-	}
-	err := r.ExtractInto(&s)
-	if err != nil {
+// LastMarker returns the ID of the last vpc on the page, which the API
+// takes as the marker of the next page.
+func (r VpcPage) LastMarker() (string, error) {
+	items, err := ExtractVpcs(r)
+	if err != nil || len(items) == 0 {
 		return "", err
 	}
-	return golangsdk.ExtractNextURL(s.Links)
+	return items[len(items)-1].ID, nil
 }
 
 // IsEmpty checks whether a VpcPage struct is empty.
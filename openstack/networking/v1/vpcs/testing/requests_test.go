@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/huaweicloud/golangsdk"
 	fake "github.com/huaweicloud/golangsdk/openstack/networking/v1/common"
 	"github.com/huaweicloud/golangsdk/openstack/networking/v1/vpcs"
 	th "github.com/huaweicloud/golangsdk/testhelper"
@@ -21,7 +23,11 @@ func TestListVpc(t *testing.T) {
 		w.Header().Add("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 
-		fmt.Fprintf(w, `
+		r.ParseForm()
+		switch marker := r.Form.Get("marker"); marker {
+		case "":
+			th.TestFormValues(t, r, map[string]string{"limit": "2"})
+			fmt.Fprintf(w, `
 {
     "vpcs": [
         {
@@ -39,7 +45,15 @@ func TestListVpc(t *testing.T) {
             "status": "OK",
             "routes": [],
             "enable_shared_snat": false
-        },
+        }
+    ]
+}
+			`)
+		case "1e5618c3-89f0-4f58-a14e-33536074ec88":
+			th.TestFormValues(t, r, map[string]string{"limit": "2", "marker": marker})
+			fmt.Fprintf(w, `
+{
+    "vpcs": [
         {
             "id": "2140264c-d313-4363-9874-9a5e18aeb516",
             "name": "test",
@@ -51,14 +65,18 @@ func TestListVpc(t *testing.T) {
     ]
 }
 			`)
+		case "2140264c-d313-4363-9874-9a5e18aeb516":
+			th.TestFormValues(t, r, map[string]string{"limit": "2", "marker": marker})
+			fmt.Fprintf(w, `{"vpcs": []}`)
+		default:
+			t.Errorf("unexpected marker %s", marker)
+		}
 	})
 
-	//count := 0
-
-	actual, err := vpcs.List(fake.ServiceClient(), vpcs.ListOpts{})
-	if err != nil {
-		t.Errorf("Failed to extract vpcs: %v", err)
-	}
+	allPages, err := vpcs.List(fake.ServiceClient(), vpcs.ListOpts{Limit: 2}).AllPages()
+	th.AssertNoErr(t, err)
+	actual, err := vpcs.ExtractVpcs(allPages)
+	th.AssertNoErr(t, err)
 
 	expected := []vpcs.Vpc{
 		{
@@ -88,6 +106,11 @@ func TestListVpc(t *testing.T) {
 	}
 
 	th.AssertDeepEquals(t, expected, actual)
+
+	named := vpcs.FilterVPCs(actual, func(v vpcs.Vpc) bool {
+		return v.Name == "vpc-ops"
+	})
+	th.AssertDeepEquals(t, expected[1:2], named)
 }
 
 func TestGetVpc(t *testing.T) {
@@ -99,6 +122,7 @@ func TestGetVpc(t *testing.T) {
 		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
 
 		w.Header().Add("Content-Type", "application/json")
+		w.Header().Add("X-Request-Id", "b9c1d7c0e5a4")
 		w.WriteHeader(http.StatusOK)
 
 		fmt.Fprintf(w, `
@@ -120,7 +144,11 @@ func TestGetVpc(t *testing.T) {
 		`)
 	})
 
-	n, err := vpcs.Get(fake.ServiceClient(), "abda1f6e-ae7c-4ff5-8d06-53425dc11f34").Extract()
+	r := vpcs.Get(fake.ServiceClient(), "abda1f6e-ae7c-4ff5-8d06-53425dc11f34")
+	th.AssertEquals(t, http.StatusOK, r.StatusCode)
+	th.AssertEquals(t, "b9c1d7c0e5a4", r.RequestID())
+
+	n, err := r.Extract()
 	th.AssertNoErr(t, err)
 	th.AssertEquals(t, "abda1f6e-ae7c-4ff5-8d06-53425dc11f34", n.ID)
 	th.AssertEquals(t, "terraform-provider-test-l90006937", n.Name)
@@ -244,3 +272,45 @@ func TestDeleteVpc(t *testing.T) {
 	res := vpcs.Delete(fake.ServiceClient(), "abda1f6e-ae7c-4ff5-8d06-53425dc11f34")
 	th.AssertNoErr(t, res.Err)
 }
+
+// handleGetVpc serves the VPC in each of statuses in turn, staying in the last
+// one.
+func handleGetVpc(t *testing.T, statuses ...string) {
+	th.Mux.HandleFunc("/v1/85636478b0bd8e67e89469c7749d4127/vpcs/abda1f6e-ae7c-4ff5-8d06-53425dc11f34", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		status := statuses[0]
+		if len(statuses) > 1 {
+			statuses = statuses[1:]
+		}
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"vpc": {"id": "abda1f6e-ae7c-4ff5-8d06-53425dc11f34", "status": "%s"}}`, status)
+	})
+}
+
+func TestWaitForStatus(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleGetVpc(t, "CREATING", "OK")
+
+	vpc, err := vpcs.WaitForStatus(fake.ServiceClient(), "abda1f6e-ae7c-4ff5-8d06-53425dc11f34", "OK", 10*time.Second)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "abda1f6e-ae7c-4ff5-8d06-53425dc11f34", vpc.ID)
+	th.CheckEquals(t, "OK", vpc.Status)
+}
+
+func TestWaitForStatusFailed(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleGetVpc(t, "ERROR")
+
+	_, err := vpcs.WaitForStatus(fake.ServiceClient(), "abda1f6e-ae7c-4ff5-8d06-53425dc11f34", "OK", 10*time.Second)
+	e, ok := err.(golangsdk.ErrUnexpectedStatus)
+	if !ok {
+		t.Fatalf("expected ErrUnexpectedStatus, got %v", err)
+	}
+	th.CheckEquals(t, "ERROR", e.Status)
+	th.CheckEquals(t, "ERROR", e.Resource.(*vpcs.Vpc).Status)
+}
@@ -1,8 +1,6 @@
 package backendecs
 
 import (
-	"log"
-
 	"github.com/huaweicloud/golangsdk"
 	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/elb"
 )
@@ -43,10 +41,10 @@ func Create(c *golangsdk.ServiceClient, opts CreateOptsBuilder, lId string) (r e
 
 	//API takes an array of these...
 	body := []map[string]interface{}{b}
-	log.Printf("[DEBUG] create ELB-BackendECS url:%q, body=%#v", rootURL(c, lId), body)
+	c.LogDebug("create ELB-BackendECS", map[string]interface{}{"url": rootURL(c, lId), "body": golangsdk.RedactBody(body)})
 
 	reqOpt := &golangsdk.RequestOpts{OkCodes: []int{200}}
-	_, r.Err = c.Post(rootURL(c, lId), body, &r.Body, reqOpt)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Post(rootURL(c, lId), body, &r.Body, reqOpt))
 	return
 }
 
@@ -72,11 +70,10 @@ func Get(c *golangsdk.ServiceClient, lId string, backendId string) (r GetResult)
 		r.Err = err
 		return
 	}
-	log.Printf("[DEBUG] get ELB-BackendECS opt=%#v, query=%s", opts, query)
 	url += query
-	log.Printf("[DEBUG] get ELB-BackendECS url:%q, backendId:%q", url, backendId)
+	c.LogDebug("get ELB-BackendECS", map[string]interface{}{"url": url, "backend_id": backendId})
 
-	_, r.Err = c.Get(url, &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Get(url, &r.Body, nil))
 	return
 }
 
@@ -118,10 +115,10 @@ func Delete(c *golangsdk.ServiceClient, lId string, opts DeleteOpts) (r elb.JobR
 		r.Err = err
 		return
 	}
-	log.Printf("[DEBUG] deleting ELB-BackendECS, request opts=%#v", b)
+	c.LogDebug("delete ELB-BackendECS", map[string]interface{}{"url": actionURL(c, lId), "body": golangsdk.RedactBody(b)})
 
-	_, r.Err = c.Post(actionURL(c, lId), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Post(actionURL(c, lId), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
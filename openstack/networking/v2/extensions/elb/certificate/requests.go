@@ -1,8 +1,6 @@
 package certificate
 
 import (
-	"log"
-
 	"github.com/huaweicloud/golangsdk"
 )
 
@@ -42,16 +40,16 @@ func Create(c *golangsdk.ServiceClient, opts CreateOptsBuilder) (r CreateResult)
 		r.Err = err
 		return
 	}
-	log.Printf("[DEBUG] create url:%q, body=%#v", rootURL(c), b)
+	c.LogDebug("create ELB-Certificate", map[string]interface{}{"url": rootURL(c), "body": golangsdk.RedactBody(b)})
 	reqOpt := &golangsdk.RequestOpts{OkCodes: []int{200}}
-	_, r.Err = c.Post(rootURL(c), b, &r.Body, reqOpt)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Post(rootURL(c), b, &r.Body, reqOpt))
 	return
 }
 
 // Get retrieves a particular Loadbalancer based on its unique ID.
 func Get(c *golangsdk.ServiceClient, id string) (r GetResult) {
 	r.ID = id
-	_, r.Err = c.Get(rootURL(c), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Get(rootURL(c), &r.Body, nil))
 	return
 }
 
@@ -90,15 +88,15 @@ func Update(c *golangsdk.ServiceClient, id string, opts UpdateOpts) (r UpdateRes
 		r.Err = err
 		return
 	}
-	_, r.Err = c.Put(resourceURL(c, id), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Put(resourceURL(c, id), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
 // Delete will permanently delete a particular Certificate based on its unique ID.
 func Delete(c *golangsdk.ServiceClient, id string) (r DeleteResult) {
 	reqOpt := &golangsdk.RequestOpts{OkCodes: []int{204}}
-	_, r.Err = c.Delete(resourceURL(c, id), reqOpt)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Delete(resourceURL(c, id), reqOpt))
 	return
 }
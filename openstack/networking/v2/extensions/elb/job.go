@@ -22,6 +22,10 @@ func (r JobResult) Extract() (*Job, error) {
 	return j, err
 }
 
+// JobInfo is the status of an ELB job.
+//
+// Deprecated: use golangsdk.Job, and golangsdk.JobTracker to wait for the job
+// to finish.
 type JobInfo struct {
 	Status     string                 `json:"status"`
 	Entities   map[string]interface{} `json:"entities"`
@@ -46,6 +50,9 @@ func (r JobInfoResult) Extract() (*JobInfo, error) {
 	return j, err
 }
 
+// QueryJobInfo queries the status of the ELB job at uri.
+//
+// Deprecated: use golangsdk.NewJobTracker(c).Get or Wait with the job's Uri.
 func QueryJobInfo(c *golangsdk.ServiceClient, uri string) (r JobInfoResult) {
 	vv := regexp.MustCompile("/v[0-9]+\\.?[0-9]*/?$")
 	e := c.ResourceBaseURL()
@@ -53,6 +60,6 @@ func QueryJobInfo(c *golangsdk.ServiceClient, uri string) (r JobInfoResult) {
 		i := strings.LastIndex(e, "/v")
 		e = e[:i]
 	}
-	_, r.Err = c.Get(e+uri, &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Get(e+uri, &r.Body, nil))
 	return
 }
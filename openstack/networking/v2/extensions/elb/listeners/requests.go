@@ -1,8 +1,6 @@
 package listeners
 
 import (
-	"log"
-
 	"github.com/huaweicloud/golangsdk"
 	"github.com/huaweicloud/golangsdk/openstack/utils"
 )
@@ -57,17 +55,16 @@ func Create(c *golangsdk.ServiceClient, opts CreateOptsBuilder, not_pass_param [
 		r.Err = err
 		return
 	}
-	log.Printf("[DEBUG] create url befor :%q, body=%#v, not_pass_param=%#v", rootURL(c), b, not_pass_param)
 	utils.DeleteNotPassParams(&b, not_pass_param)
-	log.Printf("[DEBUG] create url:%q, body=%#v", rootURL(c), b)
+	c.LogDebug("create ELB-Listener", map[string]interface{}{"url": rootURL(c), "body": golangsdk.RedactBody(b), "not_pass_params": not_pass_param})
 	reqOpt := &golangsdk.RequestOpts{OkCodes: []int{200}}
-	_, r.Err = c.Post(rootURL(c), b, &r.Body, reqOpt)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Post(rootURL(c), b, &r.Body, reqOpt))
 	return
 }
 
 // Get retrieves a particular Loadbalancer based on its unique ID.
 func Get(c *golangsdk.ServiceClient, id string) (r GetResult) {
-	_, r.Err = c.Get(resourceURL(c, id), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Get(resourceURL(c, id), &r.Body, nil))
 	return
 }
 
@@ -110,15 +107,15 @@ func Update(c *golangsdk.ServiceClient, id string, opts UpdateOpts, not_pass_par
 		return
 	}
 	utils.DeleteNotPassParams(&b, not_pass_param)
-	_, r.Err = c.Put(resourceURL(c, id), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Put(resourceURL(c, id), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
 // Delete will permanently delete a particular Listener based on its unique ID.
 func Delete(c *golangsdk.ServiceClient, id string) (r DeleteResult) {
 	reqOpt := &golangsdk.RequestOpts{OkCodes: []int{204}}
-	_, r.Err = c.Delete(resourceURL(c, id), reqOpt)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Delete(resourceURL(c, id), reqOpt))
 	return
 }
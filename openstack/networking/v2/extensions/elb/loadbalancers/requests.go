@@ -1,8 +1,6 @@
 package loadbalancers
 
 import (
-	"log"
-
 	"github.com/huaweicloud/golangsdk"
 	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/elb"
 	"github.com/huaweicloud/golangsdk/openstack/utils"
@@ -52,15 +50,15 @@ func Create(c *golangsdk.ServiceClient, opts CreateOptsBuilder) (r elb.JobResult
 		r.Err = err
 		return
 	}
-	log.Printf("[DEBUG] create ELB-LoadBalancer url:%q, body=%#v", rootURL(c), b)
+	c.LogDebug("create ELB-LoadBalancer", map[string]interface{}{"url": rootURL(c), "body": golangsdk.RedactBody(b)})
 	reqOpt := &golangsdk.RequestOpts{OkCodes: []int{200}}
-	_, r.Err = c.Post(rootURL(c), b, &r.Body, reqOpt)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Post(rootURL(c), b, &r.Body, reqOpt))
 	return
 }
 
 // Get retrieves a particular Loadbalancer based on its unique ID.
 func Get(c *golangsdk.ServiceClient, id string) (r GetResult) {
-	_, r.Err = c.Get(resourceURL(c, id), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Get(resourceURL(c, id), &r.Body, nil))
 	return
 }
 
@@ -99,15 +97,15 @@ func Update(c *golangsdk.ServiceClient, id string, opts UpdateOpts, not_pass_par
 		return
 	}
 	utils.DeleteNotPassParams(&b, not_pass_param)
-	_, r.Err = c.Put(resourceURL(c, id), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Put(resourceURL(c, id), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
 // Delete will permanently delete a particular LoadBalancer based on its unique ID.
 func Delete(c *golangsdk.ServiceClient, id string) (r elb.JobResult) {
 	reqOpt := &golangsdk.RequestOpts{OkCodes: []int{200}}
-	_, r.Err = c.Delete2(resourceURL(c, id), &r.Body, reqOpt)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Delete2(resourceURL(c, id), &r.Body, reqOpt))
 	return
 }
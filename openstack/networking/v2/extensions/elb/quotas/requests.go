@@ -6,6 +6,6 @@ import (
 
 // Get retrieves a particular Loadbalancer based on its unique ID.
 func Get(c *golangsdk.ServiceClient) (r GetResult) {
-	_, r.Err = c.Get(rootURL(c), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Get(rootURL(c), &r.Body, nil))
 	return
 }
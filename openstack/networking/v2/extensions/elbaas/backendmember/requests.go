@@ -44,9 +44,9 @@ func Add(c *golangsdk.ServiceClient, listener_id string, opts AddOptsBuilder) (r
 		r.Err = err
 		return
 	}
-	_, r.Err = c.Post(addURL(c, listener_id), a, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Post(addURL(c, listener_id), a, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
@@ -96,14 +96,14 @@ func Remove(c *golangsdk.ServiceClient, listener_id string, id string) (r Remove
 		r.Err = err
 		return
 	} */
-	_, r.Err = c.Post(removeURL(c, listener_id), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Post(removeURL(c, listener_id), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
 // Get retrieves a particular Health Monitor based on its unique ID.
 func Get(c *golangsdk.ServiceClient, listener_id, id string) (r GetResult) {
-	_, r.Err = c.Get(resourceURL(c, listener_id, id), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Get(resourceURL(c, listener_id, id), &r.Body, nil))
 	return
 }
@@ -19,6 +19,10 @@ func (r JobResult) Extract() (*Job, error) {
 	return j, err
 }
 
+// JobInfo is the status of an ELB job.
+//
+// Deprecated: use golangsdk.Job, and golangsdk.JobTracker to wait for the job
+// to finish.
 type JobInfo struct {
 	Status     string                 `json:"status"`
 	Entities   map[string]interface{} `json:"entities"`
@@ -38,7 +42,11 @@ func (r JobInfoResult) Extract() (*JobInfo, error) {
 	return j, err
 }
 
+// QueryJobInfo queries the status of the ELB job with the given ID.
+//
+// Deprecated: use golangsdk.NewJobTracker(c).Get or Wait with
+// c.ServiceURL("jobs", jobId).
 func QueryJobInfo(c *golangsdk.ServiceClient, jobId string) (r JobInfoResult) {
-	_, r.Err = c.Get(c.ServiceURL("jobs", jobId), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Get(c.ServiceURL("jobs", jobId), &r.Body, nil))
 	return
 }
@@ -2,9 +2,9 @@
 Package peerings enables management and retrieval of vpc peering connections
 
 Example to List a Vpc Peering Connections
-	   listOpts:=peerings.ListOpts{}
+	   listOpts:=peerings.ListOpts{VpcId:"3127e30b-5f8e-42d1-a3cc-fdadf412c5bf"}
 
-		peering,err :=peerings.List(client,sub).AllPages()
+		peering,err :=peerings.List(client,listOpts).AllPages()
 
 		peerings,err:=peerings.ExtractPeerings(peering)
 
@@ -13,6 +13,11 @@ Example to List a Vpc Peering Connections
 			fmt.Println(err)
 		}
 
+		// Keep the connections requested by the VPC.
+		requested:=peerings.FilterVpcPeeringConns(peerings,func(p peerings.Peering) bool {
+			return p.RequestVpcInfo.VpcId=="3127e30b-5f8e-42d1-a3cc-fdadf412c5bf"
+		})
+
 Example to Get a Vpc Peering Connection
 
        	peeringID := "6bbacb0f-9f94-4fe8-a6b6-1818bdccb2a3"
@@ -1,15 +1,20 @@
 package peerings
 
 import (
-	"reflect"
-
 	"github.com/huaweicloud/golangsdk"
 	"github.com/huaweicloud/golangsdk/pagination"
 )
 
+// ListOptsBuilder allows extensions to add additional parameters to the
+// List request.
+type ListOptsBuilder interface {
+	ToPeeringListQuery() (string, error)
+}
+
 // ListOpts allows the filtering  of paginated collections through
 // the API. Filtering is achieved by passing in struct field values that map to
-// the floating IP attributes you want to see returned.
+// the vpc_peering_connection attributes you want to see returned. Use
+// FilterVpcPeeringConns for the attributes the API cannot filter on.
 type ListOpts struct {
 	//ID is the unique identifier for the vpc_peering_connection.
 	ID string `q:"id"`
@@ -24,113 +29,78 @@ type ListOpts struct {
 	// TenantId indicates  vpc_peering_connection avalable in specific tenant.
 	TenantId string `q:"tenant_id"`
 
-	// VpcId indicates vpc_peering_connection avalable in specific vpc.
+	// VpcId indicates vpc_peering_connection avalable in specific vpc, on
+	// either side of the connection.
 	VpcId string `q:"vpc_id"`
 
-	// VpcId indicates vpc_peering_connection available in specific vpc.
-	Peer_VpcId string
-}
-
-func FilterVpcIdParam(opts ListOpts) (filter ListOpts) {
-
-	if opts.VpcId != "" {
-		filter.VpcId = opts.VpcId
-	} else {
-		filter.VpcId = opts.Peer_VpcId
-	}
+	// Marker is the ID of the last vpc_peering_connection of the previous page.
+	Marker string `q:"marker"`
 
-	filter.Name = opts.Name
-	filter.ID = opts.ID
-	filter.Status = opts.Status
-	filter.TenantId = opts.TenantId
+	// Limit is the number of vpc_peering_connections returned in a page.
+	Limit int `q:"limit"`
+}
 
-	return filter
+// ToPeeringListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToPeeringListQuery() (string, error) {
+	q, err := golangsdk.BuildQueryString(opts)
+	return q.String(), err
 }
 
 // List returns a Pager which allows you to iterate over a collection of
 // vpc_peering_connection  resources. It accepts a ListOpts struct, which allows you to
 // filter  the returned collection for greater efficiency.
-func List(c *golangsdk.ServiceClient, opts ListOpts) ([]Peering, error) {
-	filter := FilterVpcIdParam(opts)
-	q, err := golangsdk.BuildQueryString(&filter)
-	if err != nil {
-		return nil, err
-	}
-	u := rootURL(c) + q.String()
-	pages, err := pagination.NewPager(c, u, func(r pagination.PageResult) pagination.Page {
-		return PeeringConnectionPage{pagination.LinkedPageBase{PageResult: r}}
-	}).AllPages()
-
-	allPeeringConns, err := ExtractPeerings(pages)
-	if err != nil {
-		return nil, err
+func List(c *golangsdk.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := rootURL(c)
+	if opts != nil {
+		query, err := opts.ToPeeringListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
 	}
 
-	return FilterVpcPeeringConns(allPeeringConns, opts)
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		p := PeeringConnectionPage{pagination.MarkerPageBase{PageResult: r}}
+		p.MarkerPageBase.Owner = p
+		return p
+	})
 }
 
-func FilterVpcPeeringConns(peerings []Peering, opts ListOpts) ([]Peering, error) {
+// FilterVpcPeeringConns returns the vpc_peering_connections for which keep
+// returns true. It is a client-side fallback for the attributes List cannot
+// filter on, such as which side of the connection a VPC is on:
+//
+//	accepted := peerings.FilterVpcPeeringConns(allPeerings, func(p peerings.Peering) bool {
+//		return p.AcceptVpcInfo.VpcId == "c6efbdb7-dca4-4178-b3ec-692f125c1e25"
+//	})
+func FilterVpcPeeringConns(peerings []Peering, keep func(Peering) bool) []Peering {
 	var refinedPeerings []Peering
-	var matched bool
-	filterMap := map[string]interface{}{}
-
-	if opts.VpcId != "" {
-		filterMap["RequestVpcInfo"] = opts.VpcId
-	}
-
-	if opts.Peer_VpcId != "" {
-		filterMap["AcceptVpcInfo"] = opts.Peer_VpcId
-	}
-
-	if len(filterMap) > 0 && len(peerings) > 0 {
-		for _, peering := range peerings {
-			matched = true
-
-			for key, value := range filterMap {
-				if sVal := getStructNestedField(&peering, key); !(sVal == value) {
-					matched = false
-				}
-			}
-
-			if matched {
-				refinedPeerings = append(refinedPeerings, peering)
-			}
-
+	for _, peering := range peerings {
+		if keep(peering) {
+			refinedPeerings = append(refinedPeerings, peering)
 		}
-	} else {
-		refinedPeerings = peerings
 	}
-
-	return refinedPeerings, nil
-
-}
-
-func getStructNestedField(v *Peering, field string) string {
-	r := reflect.ValueOf(v)
-	f := reflect.Indirect(r).FieldByName(field).Interface()
-	r1 := reflect.ValueOf(f)
-	f1 := reflect.Indirect(r1).FieldByName("VpcId")
-	return string(f1.String())
+	return refinedPeerings
 }
 
 func Get(c *golangsdk.ServiceClient, id string) (r GetResult) {
-	_, r.Err = c.Get(resourceURL(c, id), &r.Body, nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Get(resourceURL(c, id), &r.Body, nil))
 	return
 }
 
 // Accept is used by a tenant to accept a VPC peering connection request initiated by another tenant.
 func Accept(c *golangsdk.ServiceClient, id string) (r AcceptResult) {
-	_, r.Err = c.Put(acceptURL(c, id), nil, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Put(acceptURL(c, id), nil, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
 // Reject is used by a tenant to reject a VPC peering connection request initiated by another tenant.
 func Reject(c *golangsdk.ServiceClient, id string) (r RejectResult) {
-	_, r.Err = c.Put(rejectURL(c, id), nil, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(c.Put(rejectURL(c, id), nil, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
 
@@ -158,15 +128,15 @@ func Create(client *golangsdk.ServiceClient, opts CreateOptsBuilder) (r CreateRe
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(rootURL(client), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(rootURL(client), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{201},
-	})
+	}))
 	return
 }
 
 //Delete is a method by which can be able to delete a vpc peering connection.
 func Delete(client *golangsdk.ServiceClient, id string) (r DeleteResult) {
-	_, r.Err = client.Delete(resourceURL(client, id), nil)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Delete(resourceURL(client, id), nil))
 	return
 }
 
@@ -192,8 +162,8 @@ func Update(client *golangsdk.ServiceClient, id string, opts UpdateOptsBuilder)
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Put(resourceURL(client, id), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Put(resourceURL(client, id), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes: []int{200},
-	})
+	}))
 	return
 }
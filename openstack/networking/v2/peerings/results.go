@@ -35,21 +35,17 @@ type Peering struct {
 // PeeringConnectionPage is the page returned by a pager when traversing over a
 // collection of vpc_peering_connections.
 type PeeringConnectionPage struct {
-	pagination.LinkedPageBase
+	pagination.MarkerPageBase
 }
 
-// NextPageURL is invoked when a paginated collection of vpc_peering_connections has reached
-// the end of a page and the pager seeks to traverse over a new one. In order
-// to do this, it needs to construct the next page's URL.
-func (r PeeringConnectionPage) NextPageURL() (string, error) {
-	var s struct {
-		Links []golangsdk.Link `json:"peerings_links"`
-	}
-	err := r.ExtractInto(&s)
-	if err != nil {
+// LastMarker returns the ID of the last peering on the page, which the API
+// takes as the marker of the next page.
+func (r PeeringConnectionPage) LastMarker() (string, error) {
+	items, err := ExtractPeerings(r)
+	if err != nil || len(items) == 0 {
 		return "", err
 	}
-	return golangsdk.ExtractNextURL(s.Links)
+	return items[len(items)-1].ID, nil
 }
 
 // IsEmpty checks whether a PeeringConnectionPage struct is empty.
@@ -21,6 +21,14 @@ func TestListVpcPeerings(t *testing.T) {
 		w.Header().Add("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 
+		r.ParseForm()
+		if r.Form.Get("marker") != "" {
+			th.TestFormValues(t, r, map[string]string{"vpc_id": "3127e30b-5f8e-42d1-a3cc-fdadf412c5bf", "marker": "71d64714-bd4e-44c4-917a-d8d1239e5292"})
+			fmt.Fprintf(w, `{"peerings": []}`)
+			return
+		}
+		th.TestFormValues(t, r, map[string]string{"vpc_id": "3127e30b-5f8e-42d1-a3cc-fdadf412c5bf"})
+
 		fmt.Fprintf(w, `
 {
     "peerings": [
@@ -68,12 +76,11 @@ func TestListVpcPeerings(t *testing.T) {
 			`)
 	})
 
-	//count := 0
-	actual, err := peerings.List(fake.ServiceClient(), peerings.ListOpts{})
-
-	if err != nil {
-		t.Errorf("Failed to extract vpc_peering_connections: %v", err)
-	}
+	listOpts := peerings.ListOpts{VpcId: "3127e30b-5f8e-42d1-a3cc-fdadf412c5bf"}
+	allPages, err := peerings.List(fake.ServiceClient(), listOpts).AllPages()
+	th.AssertNoErr(t, err)
+	actual, err := peerings.ExtractPeerings(allPages)
+	th.AssertNoErr(t, err)
 
 	expected := []peerings.Peering{
 		{
@@ -100,6 +107,11 @@ func TestListVpcPeerings(t *testing.T) {
 	}
 
 	th.AssertDeepEquals(t, expected, actual)
+
+	requested := peerings.FilterVpcPeeringConns(actual, func(p peerings.Peering) bool {
+		return p.RequestVpcInfo.VpcId == listOpts.VpcId
+	})
+	th.AssertDeepEquals(t, expected[:1], requested)
 }
 
 func TestCreateVpcPeeringConnection(t *testing.T) {
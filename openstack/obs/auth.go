@@ -22,7 +22,7 @@ func (obsClient ObsClient) doAuthTemporary(method, bucketName, objectKey string,
 
 	hostName := parsedRequestUrl.Host
 
-	skipAuth := obsClient.prepareHeaders(headers, hostName, isV4)
+	sp, skipAuth := obsClient.prepareHeaders(headers, hostName, isV4)
 
 	if !skipAuth {
 		if isV4 {
@@ -32,7 +32,7 @@ func (obsClient ObsClient) doAuthTemporary(method, bucketName, objectKey string,
 
 			signedHeaders, _headers := getSignedHeaders(headers)
 
-			credential, scope := getCredential(obsClient.conf.securityProvider.ak, obsClient.conf.region, shortDate)
+			credential, scope := getCredential(sp.ak, obsClient.conf.region, shortDate)
 			params[PARAM_ALGORITHM_AMZ_CAMEL] = V4_HASH_PREFIX
 			params[PARAM_CREDENTIAL_AMZ_CAMEL] = credential
 			params[PARAM_DATE_AMZ_CAMEL] = longDate
@@ -42,7 +42,7 @@ func (obsClient ObsClient) doAuthTemporary(method, bucketName, objectKey string,
 			requestUrl, canonicalizedUrl = obsClient.conf.formatUrls(bucketName, objectKey, params)
 			parsedRequestUrl, _ = url.Parse(requestUrl)
 			stringToSign := getV4StringToSign(method, canonicalizedUrl, parsedRequestUrl.RawQuery, scope, longDate, UNSIGNED_PAYLOAD, signedHeaders, _headers)
-			signature := getSignature(stringToSign, obsClient.conf.securityProvider.sk, obsClient.conf.region, shortDate)
+			signature := getSignature(stringToSign, sp.sk, obsClient.conf.region, shortDate)
 
 			requestUrl += fmt.Sprintf("&%s=%s", PARAM_SIGNATURE_AMZ_CAMEL, UrlEncode(signature, false))
 
@@ -53,21 +53,26 @@ func (obsClient ObsClient) doAuthTemporary(method, bucketName, objectKey string,
 			headers[HEADER_DATE_CAMEL] = []string{Int64ToString(expires)}
 
 			stringToSign := getV2StringToSign(method, canonicalizedUrl, headers)
-			signature := UrlEncode(Base64Encode(HmacSha1([]byte(obsClient.conf.securityProvider.sk), []byte(stringToSign))), false)
+			signature := UrlEncode(Base64Encode(HmacSha1([]byte(sp.sk), []byte(stringToSign))), false)
 			if strings.Index(requestUrl, "?") < 0 {
 				requestUrl += "?"
 			} else {
 				requestUrl += "&"
 			}
 			headers[HEADER_DATE_CAMEL] = []string{originDate}
-			requestUrl += fmt.Sprintf("AWSAccessKeyId=%s&Expires=%d&Signature=%s", UrlEncode(obsClient.conf.securityProvider.ak, false),
+			requestUrl += fmt.Sprintf("AWSAccessKeyId=%s&Expires=%d&Signature=%s", UrlEncode(sp.ak, false),
 				expires, signature)
 		}
 	}
 	return
 }
 
-func (obsClient ObsClient) prepareHeaders(headers map[string][]string, hostName string, isV4 bool) bool {
+// prepareHeaders sets the headers every request carries, and returns the keys
+// to sign it with. It reports whether there are none and the request is not
+// signed.
+func (obsClient ObsClient) prepareHeaders(headers map[string][]string, hostName string, isV4 bool) (*securityProvider, bool) {
+	sp := obsClient.getSecurityProvider()
+
 	headers[HEADER_HOST_CAMEL] = []string{hostName}
 	if date, ok := headers[HEADER_DATE_AMZ]; ok {
 		flag := false
@@ -93,15 +98,15 @@ func (obsClient ObsClient) prepareHeaders(headers map[string][]string, hostName
 		headers[HEADER_DATE_CAMEL] = []string{FormatUtcToRfc1123(time.Now().UTC())}
 	}
 
-	if obsClient.conf.securityProvider == nil || obsClient.conf.securityProvider.ak == "" || obsClient.conf.securityProvider.sk == "" {
+	if sp == nil || sp.ak == "" || sp.sk == "" {
 		doLog(LEVEL_WARN, "No ak/sk provided, skip to construct authorization")
-		return true
+		return sp, true
 	}
 
-	if obsClient.conf.securityProvider.securityToken != "" {
-		headers[HEADER_STS_TOKEN_AMZ] = []string{obsClient.conf.securityProvider.securityToken}
+	if sp.securityToken != "" {
+		headers[HEADER_STS_TOKEN_AMZ] = []string{sp.securityToken}
 	}
-	return false
+	return sp, false
 }
 
 func (obsClient ObsClient) doAuth(method, bucketName, objectKey string, params map[string]string,
@@ -120,14 +125,14 @@ func (obsClient ObsClient) doAuth(method, bucketName, objectKey string, params m
 
 	isV4 := obsClient.conf.signature == SignatureV4
 
-	skipAuth := obsClient.prepareHeaders(headers, hostName, isV4)
+	sp, skipAuth := obsClient.prepareHeaders(headers, hostName, isV4)
 
 	if !skipAuth {
 		if isV4 {
 			headers[HEADER_CONTENT_SHA256_AMZ] = []string{EMPTY_CONTENT_SHA256}
-			err = obsClient.v4Auth(method, canonicalizedUrl, parsedRequestUrl.RawQuery, headers)
+			err = obsClient.v4Auth(sp, method, canonicalizedUrl, parsedRequestUrl.RawQuery, headers)
 		} else {
-			err = obsClient.v2Auth(method, canonicalizedUrl, headers)
+			err = obsClient.v2Auth(sp, method, canonicalizedUrl, headers)
 		}
 	}
 	return
@@ -197,11 +202,11 @@ func getV2StringToSign(method, canonicalizedUrl string, headers map[string][]str
 	return stringToSign
 }
 
-func (obsClient ObsClient) v2Auth(method, canonicalizedUrl string, headers map[string][]string) error {
+func (obsClient ObsClient) v2Auth(sp *securityProvider, method, canonicalizedUrl string, headers map[string][]string) error {
 	stringToSign := getV2StringToSign(method, canonicalizedUrl, headers)
-	signature := Base64Encode(HmacSha1([]byte(obsClient.conf.securityProvider.sk), []byte(stringToSign)))
+	signature := Base64Encode(HmacSha1([]byte(sp.sk), []byte(stringToSign)))
 
-	headers[HEADER_AUTH_CAMEL] = []string{fmt.Sprintf("%s %s:%s", V2_HASH_PREFIX, obsClient.conf.securityProvider.ak, signature)}
+	headers[HEADER_AUTH_CAMEL] = []string{fmt.Sprintf("%s %s:%s", V2_HASH_PREFIX, sp.ak, signature)}
 	return nil
 }
 
@@ -281,7 +286,7 @@ func getSignature(stringToSign, sk, region, shortDate string) string {
 	return Hex(HmacSha256(key, []byte(stringToSign)))
 }
 
-func (obsClient ObsClient) v4Auth(method, canonicalizedUrl, queryUrl string, headers map[string][]string) error {
+func (obsClient ObsClient) v4Auth(sp *securityProvider, method, canonicalizedUrl, queryUrl string, headers map[string][]string) error {
 	t, err := time.Parse(RFC1123_FORMAT, headers[HEADER_DATE_CAMEL][0])
 	if err != nil {
 		t = time.Now().UTC()
@@ -291,11 +296,11 @@ func (obsClient ObsClient) v4Auth(method, canonicalizedUrl, queryUrl string, hea
 
 	signedHeaders, _headers := getSignedHeaders(headers)
 
-	credential, scope := getCredential(obsClient.conf.securityProvider.ak, obsClient.conf.region, shortDate)
+	credential, scope := getCredential(sp.ak, obsClient.conf.region, shortDate)
 
 	stringToSign := getV4StringToSign(method, canonicalizedUrl, queryUrl, scope, longDate, EMPTY_CONTENT_SHA256, signedHeaders, _headers)
 
-	signature := getSignature(stringToSign, obsClient.conf.securityProvider.sk, obsClient.conf.region, shortDate)
+	signature := getSignature(stringToSign, sp.sk, obsClient.conf.region, shortDate)
 	headers[HEADER_AUTH_CAMEL] = []string{fmt.Sprintf("%s Credential=%s,SignedHeaders=%s,Signature=%s", V4_HASH_PREFIX, credential, strings.Join(signedHeaders, ";"), signature)}
 	return nil
 }
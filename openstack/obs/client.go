@@ -1,6 +1,7 @@
 package obs
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -48,7 +49,32 @@ func New(ak, sk, endpoint string, configurers ...configurer) (*ObsClient, error)
 
 func (obsClient ObsClient) Refresh(ak, sk, securityToken string) {
 	sp := &securityProvider{ak: strings.TrimSpace(ak), sk: strings.TrimSpace(sk), securityToken: strings.TrimSpace(securityToken)}
-	obsClient.conf.securityProvider = sp
+	obsClient.conf.setSecurityProvider(sp)
+}
+
+// getSecurityProvider returns the keys to sign a request with. If the client
+// was configured with WithCredentials, the keys are replaced first when the
+// credentials were retrieved again, which Credentials only does once they
+// are about to expire.
+func (obsClient ObsClient) getSecurityProvider() *securityProvider {
+	conf := obsClient.conf
+	if conf.credentials == nil {
+		return conf.getSecurityProvider()
+	}
+	v, err := conf.credentials.Get(context.Background())
+	if err != nil {
+		doLog(LEVEL_ERROR, "Failed to retrieve credentials: %v", err)
+		return conf.getSecurityProvider()
+	}
+
+	conf.securityMut.Lock()
+	defer conf.securityMut.Unlock()
+	sp := conf.securityProvider
+	if sp == nil || sp.ak != v.AccessKey || sp.sk != v.SecretKey || sp.securityToken != v.SecurityToken {
+		sp = &securityProvider{ak: strings.TrimSpace(v.AccessKey), sk: strings.TrimSpace(v.SecretKey), securityToken: strings.TrimSpace(v.SecurityToken)}
+		conf.securityProvider = sp
+	}
+	return sp
 }
 
 func (obsClient ObsClient) Close() {
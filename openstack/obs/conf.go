@@ -11,7 +11,10 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/huaweicloud/golangsdk/auth/credentials"
 )
 
 type securityProvider struct {
@@ -27,7 +30,11 @@ type urlHolder struct {
 }
 
 type config struct {
+	// securityProvider may be replaced while requests are signed: read and
+	// write it through getSecurityProvider and setSecurityProvider.
 	securityProvider *securityProvider
+	securityMut      sync.RWMutex
+	credentials      *credentials.Credentials
 	urlHolder        *urlHolder
 	endpoint         string
 	signature        SignatureType
@@ -45,7 +52,7 @@ type config struct {
 	pemCerts         []byte
 }
 
-func (conf config) String() string {
+func (conf *config) String() string {
 	return fmt.Sprintf("[endpoint:%s, signature:%s, pathStyle:%v, region:%s"+
 		"\nconnectTimeout:%d, socketTimeout:%dheaderTimeout:%d, idleConnTimeout:%d"+
 		"\nmaxRetryCount:%d, maxConnsPerHost:%d, sslVerify:%v, proxyUrl:%s]",
@@ -134,6 +141,27 @@ func WithSecurityToken(securityToken string) configurer {
 	}
 }
 
+// WithCredentials makes the client sign requests with the keys of creds,
+// which it retrieves again whenever they are about to expire, instead of
+// the keys passed to New.
+func WithCredentials(creds *credentials.Credentials) configurer {
+	return func(conf *config) {
+		conf.credentials = creds
+	}
+}
+
+func (conf *config) getSecurityProvider() *securityProvider {
+	conf.securityMut.RLock()
+	defer conf.securityMut.RUnlock()
+	return conf.securityProvider
+}
+
+func (conf *config) setSecurityProvider(sp *securityProvider) {
+	conf.securityMut.Lock()
+	defer conf.securityMut.Unlock()
+	conf.securityProvider = sp
+}
+
 func (conf *config) initConfigWithDefault() error {
 	conf.securityProvider.ak = strings.TrimSpace(conf.securityProvider.ak)
 	conf.securityProvider.sk = strings.TrimSpace(conf.securityProvider.sk)
@@ -59,7 +59,8 @@ func (obsClient ObsClient) CreateBrowserBasedSignature(input *CreateBrowserBased
 	shortDate := date.Format(SHORT_DATE_FORMAT)
 	longDate := date.Format(LONG_DATE_FORMAT)
 
-	credential, _ := getCredential(obsClient.conf.securityProvider.ak, obsClient.conf.region, shortDate)
+	sp := obsClient.getSecurityProvider()
+	credential, _ := getCredential(sp.ak, obsClient.conf.region, shortDate)
 
 	if input.Expires <= 0 {
 		input.Expires = 300
@@ -70,8 +71,8 @@ func (obsClient ObsClient) CreateBrowserBasedSignature(input *CreateBrowserBased
 	params[PARAM_CREDENTIAL_AMZ_CAMEL] = credential
 	params[PARAM_DATE_AMZ_CAMEL] = longDate
 
-	if obsClient.conf.securityProvider.securityToken != "" {
-		params[HEADER_STS_TOKEN_AMZ] = obsClient.conf.securityProvider.securityToken
+	if sp.securityToken != "" {
+		params[HEADER_STS_TOKEN_AMZ] = sp.securityToken
 	}
 
 	matchAnyBucket := true
@@ -110,7 +111,7 @@ func (obsClient ObsClient) CreateBrowserBasedSignature(input *CreateBrowserBased
 
 	originPolicy := strings.Join(originPolicySlice, "")
 	policy := Base64Encode([]byte(originPolicy))
-	signature := getSignature(policy, obsClient.conf.securityProvider.sk, obsClient.conf.region, shortDate)
+	signature := getSignature(policy, sp.sk, obsClient.conf.region, shortDate)
 
 	output = &CreateBrowserBasedSignatureOutput{
 		OriginPolicy: originPolicy,
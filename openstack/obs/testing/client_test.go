@@ -0,0 +1,115 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/huaweicloud/golangsdk/auth/credentials"
+	"github.com/huaweicloud/golangsdk/openstack/obs"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+// handleMetadata sets up the test server as a metadata service handing out
+// numbered keys that expire after ttl, and returns the number of retrievals.
+func handleMetadata(ttl time.Duration) func() int {
+	var mu sync.Mutex
+	retrievals := 0
+	th.Mux.HandleFunc("/openstack/latest/securitykey", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		retrievals++
+		n := retrievals
+		mu.Unlock()
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"credential": {"access": "ak-%d", "secret": "sk-%d", "securitytoken": "token-%d", "expires_at": "%s"}}`,
+			n, n, n, time.Now().Add(ttl).UTC().Format(time.RFC3339))
+	})
+	return func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return retrievals
+	}
+}
+
+// handleListBuckets sets up the test server as an OBS endpoint recording the
+// access key and security token of each request.
+func handleListBuckets() func() []string {
+	var mu sync.Mutex
+	var signed []string
+	th.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		auth := strings.TrimPrefix(r.Header.Get("Authorization"), "AWS ")
+		ak := strings.SplitN(auth, ":", 2)[0]
+		mu.Lock()
+		signed = append(signed, ak+" "+r.Header.Get("x-amz-security-token"))
+		mu.Unlock()
+
+		w.Header().Add("Content-Type", "application/xml")
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><ListAllMyBucketsResult><Owner><ID>owner</ID></Owner><Buckets></Buckets></ListAllMyBucketsResult>`)
+	})
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), signed...)
+	}
+}
+
+func newObsClient(t *testing.T, creds *credentials.Credentials) *obs.ObsClient {
+	client, err := obs.New("", "", th.Endpoint(), obs.WithCredentials(creds), obs.WithMaxRetryCount(0))
+	th.AssertNoErr(t, err)
+	return client
+}
+
+func TestWithCredentialsRefresh(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	// The keys expire within the expiry window, so each request retrieves new ones.
+	retrievals := handleMetadata(time.Minute)
+	signed := handleListBuckets()
+
+	creds := credentials.NewCredentials(&credentials.MetadataProvider{BaseURL: th.Endpoint()})
+	client := newObsClient(t, creds)
+
+	for i := 0; i < 3; i++ {
+		_, err := client.ListBuckets(nil)
+		th.AssertNoErr(t, err)
+	}
+
+	th.CheckEquals(t, 3, retrievals())
+	th.CheckDeepEquals(t, []string{"ak-1 token-1", "ak-2 token-2", "ak-3 token-3"}, signed())
+}
+
+func TestWithCredentialsConcurrent(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	retrievals := handleMetadata(time.Hour)
+	signed := handleListBuckets()
+
+	creds := credentials.NewCredentials(&credentials.MetadataProvider{BaseURL: th.Endpoint()})
+	client := newObsClient(t, creds)
+
+	const requests = 20
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.ListBuckets(nil)
+			th.AssertNoErr(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// Valid keys are retrieved once and shared by every request.
+	th.CheckEquals(t, 1, retrievals())
+	all := signed()
+	th.AssertEquals(t, requests, len(all))
+	for _, s := range all {
+		th.CheckEquals(t, "ak-1 token-1", s)
+	}
+}
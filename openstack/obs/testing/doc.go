@@ -0,0 +1,2 @@
+// obs
+package testing
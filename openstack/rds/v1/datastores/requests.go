@@ -11,9 +11,9 @@ var RequestOpts golangsdk.RequestOpts = golangsdk.RequestOpts{
 //list the version informations about a specified type of database
 func List(client *golangsdk.ServiceClient, dataStoreName string) (r ListResult) {
 
-	_, r.Err = client.Get(listURL(client, dataStoreName), &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(listURL(client, dataStoreName), &r.Body, &golangsdk.RequestOpts{
 		OkCodes:     []int{200},
 		MoreHeaders: RequestOpts.MoreHeaders, JSONBody: nil,
-	})
+	}))
 	return
 }
@@ -1,7 +1,10 @@
 package instances
 
 import (
+	"time"
+
 	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/pagination"
 )
 
 var RequestOpts golangsdk.RequestOpts = golangsdk.RequestOpts{
@@ -122,10 +125,10 @@ func Create(client *golangsdk.ServiceClient, ops CreateOpsBuilder) (r CreateResu
 		return
 	}
 
-	_, r.Err = client.Post(createURL(client), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(createURL(client), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes:     []int{202},
 		MoreHeaders: RequestOpts.MoreHeaders, JSONBody: nil,
-	})
+	}))
 
 	return
 }
@@ -137,10 +140,10 @@ func UpdateVolumeSize(client *golangsdk.ServiceClient, ops UpdateOpsBuilder, id
 		return
 	}
 
-	_, r.Err = client.Post(updateURL(client, id), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(updateURL(client, id), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes:     []int{202},
 		MoreHeaders: RequestOpts.MoreHeaders, JSONBody: nil,
-	})
+	}))
 	return
 }
 
@@ -151,10 +154,10 @@ func UpdatePolicy(client *golangsdk.ServiceClient, ops UpdatePolicyOpsBuilder, i
 		return
 	}
 
-	_, r.Err = client.Put(updatePolicyURL(client, id), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Put(updatePolicyURL(client, id), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes:     []int{200},
 		MoreHeaders: RequestOpts.MoreHeaders, JSONBody: nil,
-	})
+	}))
 	return
 }
 
@@ -165,10 +168,10 @@ func UpdateFlavorRef(client *golangsdk.ServiceClient, ops UpdateFlavorOpsBuilder
 		return
 	}
 
-	_, r.Err = client.Post(updateURL(client, id), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(updateURL(client, id), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes:     []int{202},
 		MoreHeaders: RequestOpts.MoreHeaders, JSONBody: nil,
-	})
+	}))
 	return
 }
 
@@ -177,29 +180,71 @@ func Delete(client *golangsdk.ServiceClient, id string) (r DeleteResult) {
 	RequestOpts.OkCodes = []int{202}
 	RequestOpts.JSONBody = nil
 	JSONBody := make(map[string]interface{})
-	_, r.Err = client.Delete(deleteURL(client, id), &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Delete(deleteURL(client, id), &golangsdk.RequestOpts{
 		OkCodes:     []int{202},
 		MoreHeaders: RequestOpts.MoreHeaders, JSONBody: JSONBody,
-	})
+	}))
 	return
 }
 
 //get a instance with detailed information by id
 func Get(client *golangsdk.ServiceClient, id string) (r GetResult) {
 
-	_, r.Err = client.Get(getURL(client, id), &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(getURL(client, id), &r.Body, &golangsdk.RequestOpts{
 		OkCodes:     []int{200},
 		MoreHeaders: RequestOpts.MoreHeaders, JSONBody: nil,
-	})
+	}))
 	return
 }
 
+// ListOptsBuilder allows extensions to add additional parameters to the List request.
+type ListOptsBuilder interface {
+	ToInstanceListQuery() (string, error)
+}
+
+// ListOpts pages through the instances by offset and limit.
+type ListOpts struct {
+	Offset int `q:"offset"`
+	Limit  int `q:"limit"`
+}
+
+// ToInstanceListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToInstanceListQuery() (string, error) {
+	q, err := golangsdk.BuildQueryString(opts)
+	return q.String(), err
+}
+
 //list all the instances
-func List(client *golangsdk.ServiceClient) (r ListResult) {
+func List(client *golangsdk.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := listURL(client)
+	if opts != nil {
+		query, err := opts.ToInstanceListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
 
-	_, r.Err = client.Get(listURL(client), &r.Body, &golangsdk.RequestOpts{
-		OkCodes:     []int{200},
-		MoreHeaders: RequestOpts.MoreHeaders, JSONBody: nil,
+	pager := pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return InstancePage{pagination.OffsetPageBase{PageResult: r}}
 	})
-	return
+	pager.Headers = RequestOpts.MoreHeaders
+	return pager
+}
+
+// WaitForStatus polls an RDS instance until it reaches status, such as
+// "ACTIVE", or gives up after timeout. An instance entering the FAILED status
+// ends the wait early with a golangsdk.ErrUnexpectedStatus.
+func WaitForStatus(client *golangsdk.ServiceClient, id, status string, timeout time.Duration) (*Instance, error) {
+	r, err := golangsdk.WaitForStatus(func() (interface{}, string, error) {
+		inst, err := Get(client, id).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+		return inst, inst.Status, nil
+	}, []string{status}, nil, []string{"FAILED"}, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return r.(*Instance), nil
 }
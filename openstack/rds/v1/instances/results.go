@@ -1,6 +1,9 @@
 package instances
 
-import "github.com/huaweicloud/golangsdk"
+import (
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/pagination"
+)
 
 type Instance struct {
 	ID               string              `json:"id"`
@@ -97,14 +100,22 @@ type GetResult struct {
 	commonResult
 }
 
-type ListResult struct {
-	golangsdk.Result
+// InstancePage is a single page of instances, paginated by offset and limit.
+type InstancePage struct {
+	pagination.OffsetPageBase
+}
+
+// IsEmpty returns true if an InstancePage contains no instances.
+func (r InstancePage) IsEmpty() (bool, error) {
+	is, err := ExtractInstances(r)
+	return len(is) == 0, err
 }
 
-func (lr ListResult) Extract() ([]Instance, error) {
+// ExtractInstances extracts the instances from a page of List.
+func ExtractInstances(r pagination.Page) ([]Instance, error) {
 	var a struct {
 		Instances []Instance `json:"instances"`
 	}
-	err := lr.Result.ExtractInto(&a)
+	err := (r.(InstancePage)).ExtractInto(&a)
 	return a.Instances, err
 }
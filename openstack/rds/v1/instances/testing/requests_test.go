@@ -0,0 +1,104 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/rds/v1/instances"
+	"github.com/huaweicloud/golangsdk/pagination"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+	fake "github.com/huaweicloud/golangsdk/testhelper/client"
+)
+
+// handleGetInstance serves the instance in each of statuses in turn, staying in
+// the last one.
+func handleGetInstance(t *testing.T, statuses ...string) {
+	th.Mux.HandleFunc("/instances/5b6c8f1e-2a3d-4e5f-8a9b-1c2d3e4f5a6b", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		status := statuses[0]
+		if len(statuses) > 1 {
+			statuses = statuses[1:]
+		}
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"instance": {"id": "5b6c8f1e-2a3d-4e5f-8a9b-1c2d3e4f5a6b", "status": "%s"}}`, status)
+	})
+}
+
+func TestWaitForStatus(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleGetInstance(t, "BUILD", "ACTIVE")
+
+	instance, err := instances.WaitForStatus(fake.ServiceClient(), "5b6c8f1e-2a3d-4e5f-8a9b-1c2d3e4f5a6b", "ACTIVE", 10*time.Second)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "5b6c8f1e-2a3d-4e5f-8a9b-1c2d3e4f5a6b", instance.ID)
+	th.CheckEquals(t, "ACTIVE", instance.Status)
+}
+
+func TestWaitForStatusFailed(t *testing.T) {
+	for _, status := range []string{"FAILED"} {
+		th.SetupHTTP()
+		handleGetInstance(t, status)
+
+		_, err := instances.WaitForStatus(fake.ServiceClient(), "5b6c8f1e-2a3d-4e5f-8a9b-1c2d3e4f5a6b", "ACTIVE", 10*time.Second)
+		th.TeardownHTTP()
+
+		e, ok := err.(golangsdk.ErrUnexpectedStatus)
+		if !ok {
+			t.Fatalf("expected ErrUnexpectedStatus for %s, got %v", status, err)
+		}
+		th.CheckEquals(t, status, e.Status)
+		th.CheckEquals(t, status, e.Resource.(*instances.Instance).Status)
+	}
+}
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/instances", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "X-Language", "en-us")
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		r.ParseForm()
+		switch offset := r.Form.Get("offset"); offset {
+		case "":
+			th.TestFormValues(t, r, map[string]string{"limit": "2"})
+			fmt.Fprintf(w, `{"instances": [{"id": "rds-1", "status": "ACTIVE"}, {"id": "rds-2", "status": "BUILD"}]}`)
+		case "2":
+			th.TestFormValues(t, r, map[string]string{"offset": "2", "limit": "2"})
+			fmt.Fprintf(w, `{"instances": [{"id": "rds-3", "status": "ACTIVE"}]}`)
+		default:
+			t.Errorf("unexpected offset %s", offset)
+		}
+	})
+
+	var pages []int
+	err := instances.List(fake.ServiceClient(), instances.ListOpts{Limit: 2}).EachPage(func(page pagination.Page) (bool, error) {
+		list, err := instances.ExtractInstances(page)
+		if err != nil {
+			return false, err
+		}
+		pages = append(pages, len(list))
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []int{2, 1}, pages)
+
+	allPages, err := instances.List(fake.ServiceClient(), instances.ListOpts{Limit: 2}).AllPages()
+	th.AssertNoErr(t, err)
+	all, err := instances.ExtractInstances(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 3, len(all))
+	th.CheckEquals(t, "rds-3", all[2].ID)
+}
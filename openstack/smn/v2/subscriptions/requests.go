@@ -2,6 +2,7 @@ package subscriptions
 
 import (
 	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/pagination"
 )
 
 var RequestOpts golangsdk.RequestOpts = golangsdk.RequestOpts{
@@ -36,17 +37,17 @@ func Create(client *golangsdk.ServiceClient, ops CreateOpsBuilder, topicUrn stri
 		return
 	}
 
-	_, r.Err = client.Post(createURL(client, topicUrn), b, &r.Body, &golangsdk.RequestOpts{
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Post(createURL(client, topicUrn), b, &r.Body, &golangsdk.RequestOpts{
 		OkCodes:     []int{201, 200},
 		MoreHeaders: RequestOpts.MoreHeaders,
-	})
+	}))
 
 	return
 }
 
 //delete a subscription via subscription urn
 func Delete(client *golangsdk.ServiceClient, subscriptionUrn string) (r DeleteResult) {
-	_, r.Err = client.Delete(deleteURL(client, subscriptionUrn), &RequestOpts)
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Delete(deleteURL(client, subscriptionUrn), &RequestOpts))
 	return
 }
 
@@ -57,13 +58,22 @@ func Delete(client *golangsdk.ServiceClient, subscriptionUrn string) (r DeleteRe
 //}
 
 //list all the subscriptions
-func List(client *golangsdk.ServiceClient) (r ListResult) {
-	_, r.Err = client.Get(listURL(client), &r.Body, &RequestOpts)
-	return
+func List(client *golangsdk.ServiceClient) pagination.Pager {
+	return newSubscriptionPager(client, listURL(client))
 }
 
 //list all the subscriptions
-func ListFromTopic(client *golangsdk.ServiceClient, subscriptionUrn string) (r ListResult) {
-	_, r.Err = client.Get(listFromTopicURL(client, subscriptionUrn), &r.Body, &RequestOpts)
-	return
+func ListFromTopic(client *golangsdk.ServiceClient, subscriptionUrn string) pagination.Pager {
+	return newSubscriptionPager(client, listFromTopicURL(client, subscriptionUrn))
+}
+
+func newSubscriptionPager(client *golangsdk.ServiceClient, url string) pagination.Pager {
+	pager := pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return SubscriptionPage{pagination.OffsetPageBase{
+			PageResult: r,
+			TotalKey:   "subscription_count",
+		}}
+	})
+	pager.Headers = RequestOpts.MoreHeaders
+	return pager
 }
@@ -2,6 +2,7 @@ package subscriptions
 
 import (
 	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/pagination"
 )
 
 type Subscription struct {
@@ -47,14 +48,22 @@ type GetResult struct {
 	commonResult
 }
 
-type ListResult struct {
-	golangsdk.Result
+// SubscriptionPage is a single page of subscriptions, paginated by offset and limit.
+type SubscriptionPage struct {
+	pagination.OffsetPageBase
+}
+
+// IsEmpty returns true if a SubscriptionPage contains no subscriptions.
+func (r SubscriptionPage) IsEmpty() (bool, error) {
+	s, err := ExtractSubscriptions(r)
+	return len(s) == 0, err
 }
 
-func (lr ListResult) Extract() ([]SubscriptionGet, error) {
+// ExtractSubscriptions extracts the subscriptions from a page of List or ListFromTopic.
+func ExtractSubscriptions(r pagination.Page) ([]SubscriptionGet, error) {
 	var a struct {
 		Subscriptions []SubscriptionGet `json:"subscriptions"`
 	}
-	err := lr.Result.ExtractInto(&a)
+	err := (r.(SubscriptionPage)).ExtractInto(&a)
 	return a.Subscriptions, err
 }
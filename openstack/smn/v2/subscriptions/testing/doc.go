@@ -0,0 +1,2 @@
+// subscriptions unit tests
+package testing
@@ -0,0 +1,89 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/huaweicloud/golangsdk/openstack/smn/v2/subscriptions"
+	"github.com/huaweicloud/golangsdk/pagination"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+	fake "github.com/huaweicloud/golangsdk/testhelper/client"
+)
+
+const topicUrn = "urn:smn:cn-north-1:3f8ad9b2:topic1"
+
+// handleListSubscriptions serves three subscriptions at path, two on the
+// first page and one on the second.
+func handleListSubscriptions(t *testing.T, path string) {
+	th.Mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "X-Language", "en-us")
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		r.ParseForm()
+		switch offset := r.Form.Get("offset"); offset {
+		case "0":
+			th.TestFormValues(t, r, map[string]string{"offset": "0", "limit": "100"})
+			fmt.Fprintf(w, `
+{
+    "request_id": "6a63a18b8bab40ffb71ebd9cb80d0085",
+    "subscription_count": 3,
+    "subscriptions": [
+        {"topic_urn": "%[1]s", "protocol": "email", "subscription_urn": "%[1]s:sub1", "endpoint": "a@example.com", "status": 1},
+        {"topic_urn": "%[1]s", "protocol": "sms", "subscription_urn": "%[1]s:sub2", "endpoint": "+8613600000000", "status": 0}
+    ]
+}`, topicUrn)
+		case "2":
+			th.TestFormValues(t, r, map[string]string{"offset": "2", "limit": "100"})
+			fmt.Fprintf(w, `
+{
+    "request_id": "6a63a18b8bab40ffb71ebd9cb80d0086",
+    "subscription_count": 3,
+    "subscriptions": [
+        {"topic_urn": "%[1]s", "protocol": "http", "subscription_urn": "%[1]s:sub3", "endpoint": "http://example.com", "status": 1}
+    ]
+}`, topicUrn)
+		default:
+			t.Errorf("unexpected offset %s", offset)
+		}
+	})
+}
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleListSubscriptions(t, "/subscriptions")
+
+	var pages []int
+	err := subscriptions.List(fake.ServiceClient()).EachPage(func(page pagination.Page) (bool, error) {
+		list, err := subscriptions.ExtractSubscriptions(page)
+		if err != nil {
+			return false, err
+		}
+		pages = append(pages, len(list))
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []int{2, 1}, pages)
+}
+
+func TestListFromTopic(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleListSubscriptions(t, "/topics/"+topicUrn+"/subscriptions")
+
+	allPages, err := subscriptions.ListFromTopic(fake.ServiceClient(), topicUrn).AllPages()
+	th.AssertNoErr(t, err)
+	all, err := subscriptions.ExtractSubscriptions(allPages)
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, 3, len(all))
+	th.CheckEquals(t, topicUrn+":sub1", all[0].SubscriptionUrn)
+	th.CheckEquals(t, "email", all[0].Protocol)
+	th.CheckEquals(t, 1, all[0].Status)
+	th.CheckEquals(t, topicUrn+":sub3", all[2].SubscriptionUrn)
+}
@@ -0,0 +1,189 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack"
+	"github.com/huaweicloud/golangsdk/openstack/identity/v3/tokens"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+// factoryServer issues domain tokens for password requests and rescopes
+// them to projects for token requests.
+type factoryServer struct {
+	sync.Mutex
+	domainTokens int
+	rescopes     map[string]int
+	revoked      map[string]bool
+}
+
+func handleFactoryTokens(t *testing.T) *factoryServer {
+	s := &factoryServer{rescopes: make(map[string]int), revoked: make(map[string]bool)}
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", "")
+
+		var body struct {
+			Auth struct {
+				Identity struct {
+					Methods []string `json:"methods"`
+					Token   struct {
+						ID string `json:"id"`
+					} `json:"token"`
+				} `json:"identity"`
+				Scope struct {
+					Domain struct {
+						Name string `json:"name"`
+					} `json:"domain"`
+					Project struct {
+						ID string `json:"id"`
+					} `json:"project"`
+				} `json:"scope"`
+			} `json:"auth"`
+		}
+		th.AssertNoErr(t, json.NewDecoder(r.Body).Decode(&body))
+
+		s.Lock()
+		defer s.Unlock()
+
+		if body.Auth.Identity.Methods[0] == "password" {
+			th.CheckEquals(t, "ops", body.Auth.Scope.Domain.Name)
+			s.domainTokens++
+			w.Header().Add("X-Subject-Token", fmt.Sprintf("domain-token-%d", s.domainTokens))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token": {"expires_at": "2099-02-27T18:30:59.999999Z"}}`)
+			return
+		}
+
+		if s.revoked[body.Auth.Identity.Token.ID] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		project := body.Auth.Scope.Project.ID
+		s.rescopes[project]++
+		w.Header().Add("X-Subject-Token", "token-"+project)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `
+			{
+				"token": {
+					"expires_at": "2099-02-27T18:30:59.999999Z",
+					"project": {"id": "%[1]s"},
+					"catalog": [
+						{
+							"type": "network",
+							"endpoints": [
+								{"interface": "public", "region": "cn-north-1", "url": "https://vpc.cn-north-1.example.com/%[1]s"},
+								{"interface": "public", "region": "cn-east-2", "url": "https://vpc.cn-east-2.example.com/%[1]s"}
+							]
+						}
+					]
+				}
+			}
+		`, project)
+	})
+	return s
+}
+
+func newClientFactory(t *testing.T) *openstack.ClientFactory {
+	provider, err := openstack.NewClient(th.Endpoint() + "v3")
+	th.AssertNoErr(t, err)
+
+	factory, err := openstack.NewClientFactory(provider, &tokens.AuthOptions{
+		Username:   "ops-user",
+		Password:   "secret",
+		DomainName: "ops",
+		Scope:      tokens.Scope{DomainName: "ops"},
+	})
+	th.AssertNoErr(t, err)
+	return factory
+}
+
+func TestClientFactoryServiceClients(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	server := handleFactoryTokens(t)
+	factory := newClientFactory(t)
+	factory.EndpointOpts = golangsdk.EndpointOpts{
+		Overrides: map[string]string{"dns": "https://dns.example.com"},
+	}
+
+	client, err := factory.ServiceClient("p1", "cn-north-1", "network")
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://vpc.cn-north-1.example.com/p1/", client.Endpoint)
+	th.CheckEquals(t, "token-p1", client.Token())
+
+	client, err = factory.ServiceClient("p2", "cn-east-2", "network")
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://vpc.cn-east-2.example.com/p2/", client.Endpoint)
+	th.CheckEquals(t, "token-p2", client.Token())
+
+	client, err = factory.ServiceClient("p1", "cn-north-1", "dns")
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://dns.example.com/", client.Endpoint)
+
+	th.CheckEquals(t, 1, server.domainTokens)
+	th.CheckEquals(t, 1, server.rescopes["p1"])
+	th.CheckEquals(t, 1, server.rescopes["p2"])
+}
+
+func TestClientFactoryConcurrentProjects(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	server := handleFactoryTokens(t)
+	factory := newClientFactory(t)
+
+	projects := []string{"p1", "p2", "p3"}
+	clients := make([]*golangsdk.ProviderClient, 30)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			client, err := factory.ProviderClient(projects[i%len(projects)])
+			th.AssertNoErr(t, err)
+			clients[i] = client
+		}(i)
+	}
+	wg.Wait()
+
+	for i, client := range clients {
+		th.CheckEquals(t, clients[i%len(projects)], client)
+		th.CheckEquals(t, "token-"+projects[i%len(projects)], client.Token())
+	}
+	th.CheckEquals(t, 1, server.domainTokens)
+	for _, project := range projects {
+		th.CheckEquals(t, 1, server.rescopes[project])
+	}
+}
+
+func TestClientFactoryReauth(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	server := handleFactoryTokens(t)
+	factory := newClientFactory(t)
+
+	client, err := factory.ProviderClient("p1")
+	th.AssertNoErr(t, err)
+
+	// Re-authentication rescopes the cached domain token.
+	th.AssertNoErr(t, client.ReauthFunc())
+	th.CheckEquals(t, "token-p1", client.Token())
+	th.CheckEquals(t, 1, server.domainTokens)
+	th.CheckEquals(t, 2, server.rescopes["p1"])
+
+	// A revoked domain token is replaced once.
+	server.Lock()
+	server.revoked["domain-token-1"] = true
+	server.Unlock()
+
+	th.AssertNoErr(t, client.ReauthFunc())
+	th.CheckEquals(t, "token-p1", client.Token())
+	th.CheckEquals(t, 2, server.domainTokens)
+	th.CheckEquals(t, 3, server.rescopes["p1"])
+}
@@ -1,11 +1,17 @@
 package testing
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/auth/credentials"
 	"github.com/huaweicloud/golangsdk/openstack"
 	th "github.com/huaweicloud/golangsdk/testhelper"
 )
@@ -322,3 +328,193 @@ func TestAuthenticatedClientV3Fails(t *testing.T) {
 func TestAuthenticatedClientV2Fails(t *testing.T) {
 	testAuthenticatedClientFails(t, "http://bad-address.example.com/v2.0")
 }
+
+func TestAuthenticatedClientAKSK(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("AK/SK authentication must not request a token")
+	})
+
+	th.Mux.HandleFunc("/v3/auth/catalog", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Project-Id", "263fd9")
+		th.TestHeader(t, r, "X-Auth-Token", "")
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "SDK-HMAC-SHA256 Access=access, ") {
+			t.Errorf("unexpected Authorization header: %s", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("X-Sdk-Date") == "" {
+			t.Errorf("missing X-Sdk-Date header")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `
+			{
+				"catalog": [
+					{
+						"endpoints": [
+							{
+								"id": "39dc322ce86c4111b4f06c2eeae0841b",
+								"interface": "public",
+								"region": "RegionOne",
+								"url": "https://vpc.example.com/"
+							}
+						],
+						"id": "4363ae44bdf34a3981fde3b823cb9aa2",
+						"type": "network",
+						"name": "vpc"
+					}
+				]
+			}
+		`)
+	})
+
+	options := golangsdk.AKSKAuthOptions{
+		IdentityEndpoint: th.Endpoint() + "v3",
+		ProjectId:        "263fd9",
+		AccessKey:        "access",
+		SecretKey:        "secret",
+	}
+	client, err := openstack.AuthenticatedClient(options)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "", client.TokenID)
+	th.CheckEquals(t, "263fd9", client.ProjectID)
+
+	sc, err := openstack.NewNetworkV1(client, golangsdk.EndpointOpts{Region: "RegionOne"})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://vpc.example.com/", sc.Endpoint)
+}
+
+func TestAuthenticateV3TokenCache(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	requests := 0
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Add("X-Subject-Token", ID)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `
+			{
+				"token": {
+					"expires_at": "2099-02-02T18:30:59.000000Z",
+					"project": {
+						"id": "263fd9"
+					},
+					"catalog": [
+						{
+							"endpoints": [
+								{
+									"id": "39dc322ce86c4111b4f06c2eeae0841b",
+									"interface": "public",
+									"region": "RegionOne",
+									"url": "https://vpc.example.com/"
+								}
+							],
+							"id": "4363ae44bdf34a3981fde3b823cb9aa2",
+							"type": "network",
+							"name": "vpc"
+						}
+					]
+				}
+			}
+		`)
+	})
+
+	dir, err := ioutil.TempDir("", "golangsdk-tokens")
+	th.AssertNoErr(t, err)
+	defer os.RemoveAll(dir)
+	cache := golangsdk.NewFileTokenCache(dir)
+
+	authenticate := func(username, password, secret string) *golangsdk.ProviderClient {
+		client, err := openstack.NewClient(th.Endpoint() + "v3")
+		th.AssertNoErr(t, err)
+		client.TokenCache = cache
+		client.TokenCacheSecret = []byte(secret)
+		err = openstack.Authenticate(client, golangsdk.AuthOptions{
+			IdentityEndpoint: th.Endpoint() + "v3",
+			Username:         username,
+			Password:         password,
+			DomainName:       "default",
+			TenantID:         "263fd9",
+		})
+		th.AssertNoErr(t, err)
+		return client
+	}
+
+	authenticate("me", "s3cr3t-pa55", "shared")
+	th.CheckEquals(t, 1, requests)
+
+	client := authenticate("me", "s3cr3t-pa55", "shared")
+	th.CheckEquals(t, 1, requests)
+	th.CheckEquals(t, ID, client.TokenID)
+	th.CheckEquals(t, "263fd9", client.ProjectID)
+	sc, err := openstack.NewNetworkV1(client, golangsdk.EndpointOpts{Region: "RegionOne"})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://vpc.example.com/", sc.Endpoint)
+
+	authenticate("someone-else", "s3cr3t-pa55", "shared")
+	th.CheckEquals(t, 2, requests)
+
+	// A different password never gets the token of the previous one.
+	authenticate("me", "rotated-pa55", "shared")
+	th.CheckEquals(t, 3, requests)
+
+	// Neither does a client keyed with another secret.
+	authenticate("me", "s3cr3t-pa55", "other")
+	th.CheckEquals(t, 4, requests)
+
+	// Without a secret, clients of the process share the per-process one.
+	authenticate("me", "s3cr3t-pa55", "")
+	th.CheckEquals(t, 5, requests)
+	authenticate("me", "s3cr3t-pa55", "")
+	th.CheckEquals(t, 5, requests)
+
+	// The cache doesn't hold the passwords themselves.
+	files, err := ioutil.ReadDir(dir)
+	th.AssertNoErr(t, err)
+	for _, f := range files {
+		b, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		th.AssertNoErr(t, err)
+		if strings.Contains(string(b), "pa55") {
+			t.Errorf("cache file %s holds a password", f.Name())
+		}
+	}
+}
+
+type staticCredentials credentials.Value
+
+func (s staticCredentials) Retrieve(ctx context.Context) (credentials.Value, error) {
+	return credentials.Value(s), nil
+}
+
+func TestAuthenticateAKSKWithCredentials(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v3/auth/catalog", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Project-Id", "263fd9")
+		th.TestHeader(t, r, "X-Security-Token", "token")
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "SDK-HMAC-SHA256 Access=from-credentials, ") {
+			t.Errorf("unexpected Authorization header: %s", r.Header.Get("Authorization"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"catalog": []}`)
+	})
+
+	client, err := openstack.NewClient(th.Endpoint() + "v3")
+	th.AssertNoErr(t, err)
+	client.Credentials = credentials.NewCredentials(staticCredentials{
+		AccessKey:     "from-credentials",
+		SecretKey:     "secret",
+		SecurityToken: "token",
+	})
+
+	err = openstack.Authenticate(client, golangsdk.AKSKAuthOptions{
+		IdentityEndpoint: th.Endpoint() + "v3",
+		ProjectId:        "263fd9",
+	})
+	th.AssertNoErr(t, err)
+}
@@ -229,3 +229,103 @@ func TestV3EndpointBadAvailability(t *testing.T) {
 	})
 	th.CheckEquals(t, "Unexpected availability in endpoint query: wat", err.Error())
 }
+
+func catalogProvider() *golangsdk.ProviderClient {
+	return &golangsdk.ProviderClient{
+		EndpointLocator: func(eo golangsdk.EndpointOpts) (string, error) {
+			switch eo.Type {
+			case "compute":
+				return "https://ecs.cn-north-1.myhuaweicloud.com/v2/263fd9/", nil
+			case "network":
+				return "https://vpc.cn-north-1.myhuaweicloud.com/", nil
+			}
+			return "", &golangsdk.ErrEndpointNotFound{}
+		},
+	}
+}
+
+func TestEndpointOverrides(t *testing.T) {
+	provider := catalogProvider()
+	eo := golangsdk.EndpointOpts{
+		Overrides: map[string]string{
+			"compute": "https://ecs.example.com/v2/263fd9",
+			"nat":     "https://nat.example.com",
+		},
+	}
+
+	sc, err := openstack.NewComputeV2(provider, eo)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://ecs.example.com/v2/263fd9/", sc.Endpoint)
+
+	// Services derived from an overridden service type derive from the override.
+	sc, err = openstack.NewRdsServiceV1(provider, eo)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://rds.example.com/rds/v1/263fd9/", sc.Endpoint)
+
+	sc, err = openstack.NewNatV2(provider, eo)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://nat.example.com/", sc.Endpoint)
+	th.CheckEquals(t, "https://nat.example.com/v2.0/", sc.ResourceBase)
+	th.CheckEquals(t, "nat", sc.Type)
+
+	sc, err = openstack.NewNetworkV2(provider, eo)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://vpc.cn-north-1.myhuaweicloud.com/", sc.Endpoint)
+}
+
+func TestEndpointDerivedFromCatalog(t *testing.T) {
+	sc, err := openstack.NewNatV2(catalogProvider(), golangsdk.EndpointOpts{})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://nat.cn-north-1.myhuaweicloud.com/", sc.Endpoint)
+
+	sc, err = openstack.NewKmsKeyV1(catalogProvider(), golangsdk.EndpointOpts{})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://kms.cn-north-1.myhuaweicloud.com/v1.0/", sc.Endpoint)
+}
+
+func TestEndpointTemplate(t *testing.T) {
+	eo := golangsdk.EndpointOpts{
+		Region:   "cn-north-1",
+		Template: "https://{service}.{region}.{domain}/",
+		Domain:   "myhuaweicloud.com",
+	}
+
+	// The catalog is preferred to the template.
+	sc, err := openstack.NewNetworkV1(catalogProvider(), eo)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://vpc.cn-north-1.myhuaweicloud.com/", sc.Endpoint)
+
+	sc, err = openstack.NewDMSServiceV1(catalogProvider(), eo)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://dms.cn-north-1.myhuaweicloud.com/", sc.Endpoint)
+
+	// Without a service catalog, only overrides and the template are used.
+	sc, err = openstack.NewDCSServiceV1(&golangsdk.ProviderClient{}, eo)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://dcs.cn-north-1.myhuaweicloud.com/", sc.Endpoint)
+}
+
+func TestEndpointNotResolved(t *testing.T) {
+	// Without a template, the error of the service catalog is returned as is.
+	_, err := openstack.NewDMSServiceV1(catalogProvider(), golangsdk.EndpointOpts{})
+	if _, ok := err.(*golangsdk.ErrEndpointNotFound); !ok {
+		t.Fatalf("expected *golangsdk.ErrEndpointNotFound, got %T: %v", err, err)
+	}
+
+	_, err = openstack.NewDMSServiceV1(catalogProvider(), golangsdk.EndpointOpts{
+		Template: "https://{service}.{region}.example.com/",
+	})
+	e, ok := err.(openstack.ErrEndpointNotResolved)
+	if !ok {
+		t.Fatalf("expected ErrEndpointNotResolved, got %T: %v", err, err)
+	}
+	th.CheckEquals(t, "dms", e.Type)
+	th.CheckDeepEquals(t, &golangsdk.ErrEndpointNotFound{}, e.Err)
+	th.CheckEquals(t, "No endpoint could be resolved for service type dms: no endpoint override; "+
+		"service catalog (type dms): No suitable endpoint could be found in the service catalog.; "+
+		"endpoint template https://{service}.{region}.example.com/: Missing input for argument [Region]", err.Error())
+
+	_, err = openstack.NewDMSServiceV1(&golangsdk.ProviderClient{}, golangsdk.EndpointOpts{})
+	th.CheckEquals(t, "No endpoint could be resolved for service type dms: no endpoint override; "+
+		"no service catalog; no endpoint template", err.Error())
+}
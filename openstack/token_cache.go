@@ -0,0 +1,150 @@
+package openstack
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	tokens3 "github.com/huaweicloud/golangsdk/openstack/identity/v3/tokens"
+)
+
+// tokenCacheKey returns the key under which the tokens issued by the identity
+// service at endpoint for opts are cached. Only password authentication is
+// cached: the key holds the user, its domain, the requested scope and an
+// HMAC-SHA256 of the password keyed with secret, so that tokens are never
+// shared between different credentials and the password can't be recovered
+// from the key without the secret. An empty key means the token must not be
+// cached.
+func tokenCacheKey(endpoint string, opts tokens3.AuthOptionsBuilder, secret []byte) string {
+	scope, err := opts.ToTokenV3ScopeMap()
+	if err != nil {
+		return ""
+	}
+	body, err := opts.ToTokenV3CreateMap(scope)
+	if err != nil {
+		return ""
+	}
+
+	// Round-trip the request body through JSON to walk it generically.
+	var req struct {
+		Auth struct {
+			Identity struct {
+				Password *struct {
+					User map[string]interface{} `json:"user"`
+				} `json:"password"`
+			} `json:"identity"`
+			Scope interface{} `json:"scope"`
+		} `json:"auth"`
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return ""
+	}
+	if err := json.Unmarshal(b, &req); err != nil {
+		return ""
+	}
+
+	password := req.Auth.Identity.Password
+	if password == nil || password.User == nil {
+		return ""
+	}
+	pass, _ := password.User["password"].(string)
+	delete(password.User, "password")
+	if len(secret) == 0 {
+		secret = processTokenCacheSecret()
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(pass))
+
+	key, err := json.Marshal(map[string]interface{}{
+		"endpoint": endpoint,
+		"user":     password.User,
+		"scope":    req.Auth.Scope,
+		"secret":   hex.EncodeToString(mac.Sum(nil)),
+	})
+	if err != nil {
+		return ""
+	}
+	return string(key)
+}
+
+var (
+	tokenCacheSecretOnce sync.Once
+	tokenCacheSecret     []byte
+)
+
+// processTokenCacheSecret returns the random secret used by the clients of
+// the process that don't set TokenCacheSecret.
+func processTokenCacheSecret() []byte {
+	tokenCacheSecretOnce.Do(func() {
+		tokenCacheSecret = make([]byte, 32)
+		rand.Read(tokenCacheSecret)
+	})
+	return tokenCacheSecret
+}
+
+// cachedToken returns the result of the token request cached under key, if
+// it holds a token that does not expire within the client's refresh window.
+func cachedToken(client *golangsdk.ProviderClient, key string) (tokens3.CreateResult, bool) {
+	var r tokens3.CreateResult
+	if client.TokenCache == nil || key == "" {
+		return r, false
+	}
+
+	cached, err := client.TokenCache.Get(key)
+	if err != nil || cached == nil {
+		return r, false
+	}
+
+	window := client.TokenRefreshWindow
+	if window == 0 {
+		window = golangsdk.DefaultTokenRefreshWindow
+	}
+	if time.Until(cached.ExpiresAt) <= window {
+		return r, false
+	}
+
+	if err := json.Unmarshal(cached.Body, &r.Body); err != nil {
+		return r, false
+	}
+	r.Header = http.Header{}
+	r.Header.Set("X-Subject-Token", cached.ID)
+	return r, true
+}
+
+// cacheToken stores the result of a token request under key. Failing to
+// cache a token is not an error: the client just authenticates again next
+// time.
+func cacheToken(client *golangsdk.ProviderClient, key string, r tokens3.CreateResult, token *tokens3.Token) {
+	if client.TokenCache == nil || key == "" {
+		return
+	}
+	body, err := json.Marshal(r.Body)
+	if err != nil {
+		return
+	}
+	client.TokenCache.Set(key, &golangsdk.CachedToken{
+		ID:        token.ID,
+		ExpiresAt: token.ExpiresAt,
+		Body:      body,
+	})
+}
+
+// evictCachedToken removes the token cached under key if it is stale, the
+// token the client is re-authenticating because of. A newer token cached by
+// another client is kept.
+func evictCachedToken(client *golangsdk.ProviderClient, key, stale string) {
+	if client.TokenCache == nil || key == "" {
+		return
+	}
+	cached, err := client.TokenCache.Get(key)
+	if err == nil && cached != nil && cached.ID == stale {
+		client.TokenCache.Delete(key)
+	}
+}
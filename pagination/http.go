@@ -1,6 +1,7 @@
 package pagination
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -53,7 +54,12 @@ func PageResultFromParsed(resp *http.Response, body interface{}) PageResult {
 
 // Request performs an HTTP request and extracts the http.Response from the result.
 func Request(client *golangsdk.ServiceClient, headers map[string]string, url string) (*http.Response, error) {
-	return client.Get(url, nil, &golangsdk.RequestOpts{
+	return RequestWithContext(context.Background(), client, headers, url)
+}
+
+// RequestWithContext is like Request, but binds the HTTP request to ctx.
+func RequestWithContext(ctx context.Context, client *golangsdk.ServiceClient, headers map[string]string, url string) (*http.Response, error) {
+	return client.GetCtx(ctx, url, nil, &golangsdk.RequestOpts{
 		MoreHeaders: headers,
 		OkCodes:     []int{200, 204, 300},
 	})
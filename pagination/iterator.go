@@ -0,0 +1,221 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/huaweicloud/golangsdk"
+)
+
+// PrefetchPage is a Page that knows the URLs of several pages after it before they are fetched,
+// such as a page embedding OffsetPageBase. An Iterator fetches those pages concurrently.
+type PrefetchPage interface {
+	Page
+
+	// NextPageURLs returns the URLs of up to n pages after this one, in order.
+	// Return nil if no such page exists.
+	NextPageURLs(n int) ([]string, error)
+}
+
+// Iterator streams the items of a Pager one at a time, holding only the pages it is iterating or
+// prefetching in memory. Create one with Pager.Iterator:
+//
+//	it := queues.List(client, false).Iterator(func(page pagination.Page) (interface{}, error) {
+//		return queues.ExtractQueues(page)
+//	}, 2)
+//	defer it.Close()
+//
+//	for it.Next() {
+//		queue := it.Item().(queues.Queue)
+//	}
+//	if err := it.Err(); err != nil {
+//		panic(err)
+//	}
+type Iterator struct {
+	pager    Pager
+	extract  func(Page) (interface{}, error)
+	prefetch int
+	cancel   context.CancelFunc
+
+	// urls holds the pages that are known but not yet requested, and
+	// pending the pages being fetched, both in order.
+	urls    []string
+	pending []chan fetchedPage
+
+	items reflect.Value
+	index int
+	item  interface{}
+
+	err  error
+	done bool
+}
+
+type fetchedPage struct {
+	page Page
+	err  error
+}
+
+// Iterator returns an Iterator over the items of p. extract returns the items of a page as a slice,
+// and is typically a wrapper around the Extract function of the page type. Up to prefetch pages
+// after the current one are fetched in the background: all of them when the pages implement
+// PrefetchPage, otherwise only the next one. Items are returned in the order of the pages.
+//
+// The Iterator stops at the first empty page, as EachPage does. Call Close to release it when
+// leaving the loop early.
+func (p Pager) Iterator(extract func(Page) (interface{}, error), prefetch int) *Iterator {
+	ctx, cancel := context.WithCancel(p.context())
+	it := &Iterator{
+		pager:    p.WithContext(ctx),
+		extract:  extract,
+		prefetch: prefetch,
+		cancel:   cancel,
+		urls:     []string{p.initialURL},
+	}
+	if p.Err != nil {
+		it.fail(p.Err)
+	}
+	return it
+}
+
+// Next advances to the next item, which is then available from Item. It returns false once the
+// items are exhausted or an error occurred, which is then available from Err.
+func (it *Iterator) Next() bool {
+	for {
+		if it.items.IsValid() && it.index < it.items.Len() {
+			it.item = it.items.Index(it.index).Interface()
+			it.index++
+			return true
+		}
+		it.item = nil
+		if it.done {
+			return false
+		}
+		it.nextPage()
+	}
+}
+
+// Item returns the item Next advanced to.
+func (it *Iterator) Item() interface{} {
+	return it.item
+}
+
+// Err returns the error that stopped the Iterator, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// Close stops the Iterator and aborts the page requests in flight. It is safe to call Close
+// more than once, and after the items are exhausted.
+func (it *Iterator) Close() {
+	it.done = true
+	it.items = reflect.Value{}
+	it.urls, it.pending = nil, nil
+	it.cancel()
+}
+
+func (it *Iterator) fail(err error) {
+	it.err = err
+	it.Close()
+}
+
+// nextPage waits for the next page and makes its items current.
+func (it *Iterator) nextPage() {
+	if len(it.pending) == 0 {
+		if len(it.urls) == 0 {
+			it.Close()
+			return
+		}
+		it.start()
+	}
+
+	fetched := <-it.pending[0]
+	it.pending = it.pending[1:]
+	if fetched.err != nil {
+		it.fail(fetched.err)
+		return
+	}
+	page := fetched.page
+
+	empty, err := page.IsEmpty()
+	if err != nil {
+		it.fail(err)
+		return
+	}
+	if empty {
+		it.Close()
+		return
+	}
+
+	items, err := it.extract(page)
+	if err != nil {
+		it.fail(err)
+		return
+	}
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice {
+		err := golangsdk.ErrUnexpectedType{}
+		err.Expected = "a slice"
+		err.Actual = fmt.Sprintf("%T", items)
+		it.fail(err)
+		return
+	}
+	it.items, it.index = v, 0
+
+	if err := it.findURLs(page); err != nil {
+		it.fail(err)
+		return
+	}
+	for len(it.pending) < it.prefetch && len(it.urls) > 0 {
+		it.start()
+	}
+}
+
+// findURLs learns the URLs of the pages after page which are not pending yet.
+func (it *Iterator) findURLs(page Page) error {
+	if p, ok := page.(PrefetchPage); ok {
+		n := it.prefetch
+		if n < 1 {
+			n = 1
+		}
+		urls, err := p.NextPageURLs(len(it.pending) + n)
+		if err != nil {
+			return err
+		}
+		if len(urls) > len(it.pending) {
+			it.urls = urls[len(it.pending):]
+		} else {
+			it.urls = nil
+		}
+		return nil
+	}
+
+	// Only the URL of the page right after this one can be known, and
+	// only once this page is fetched.
+	if len(it.pending) > 0 || len(it.urls) > 0 {
+		return nil
+	}
+	url, err := page.NextPageURL()
+	if err != nil {
+		return err
+	}
+	if url != "" {
+		it.urls = []string{url}
+	}
+	return nil
+}
+
+// start fetches the first known URL in the background.
+func (it *Iterator) start() {
+	url := it.urls[0]
+	it.urls = it.urls[1:]
+
+	ch := make(chan fetchedPage, 1)
+	it.pending = append(it.pending, ch)
+
+	pager := it.pager
+	go func() {
+		page, err := pager.fetchNextPage(url)
+		ch <- fetchedPage{page: page, err: err}
+	}()
+}
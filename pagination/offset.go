@@ -0,0 +1,173 @@
+package pagination
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/huaweicloud/golangsdk"
+)
+
+// OffsetPageBase is a page in a collection that's paginated by an offset and a "limit" query parameter.
+// The URL of the next page is derived from the query string of the current one and, when the response
+// reports it, the total number of items in the collection.
+type OffsetPageBase struct {
+	PageResult
+
+	// OffsetParam is the query parameter holding the position of the page, such as "start_number".
+	// When left as "", "offset" will be used as a default.
+	OffsetParam string
+
+	// LimitParam is the query parameter holding the maximum number of items in a page.
+	// When left as "", "limit" will be used as a default.
+	LimitParam string
+
+	// PageNumbers indicates that OffsetParam numbers pages rather than items.
+	PageNumbers bool
+
+	// FirstPage is the number of the first page, used when PageNumbers is set and the
+	// current URL has no OffsetParam.
+	FirstPage int
+
+	// TotalKey is the key of the total number of items within the response, such as "total_number".
+	// When left as "", "total" will be used as a default. Without a total, the last page is the
+	// first one holding fewer items than the limit.
+	TotalKey string
+
+	// ItemsKey is the key of the items within the response.
+	// When left as "", the only list in the response will be used.
+	ItemsKey string
+}
+
+// NextPageURL generates the URL for the page of results after this one.
+func (current OffsetPageBase) NextPageURL() (string, error) {
+	urls, err := current.NextPageURLs(1)
+	if err != nil || len(urls) == 0 {
+		return "", err
+	}
+	return urls[0], nil
+}
+
+// NextPageURLs generates the URLs for up to n pages of results after this one, which lets
+// an Iterator fetch them concurrently. Pages past the next one are only known when the
+// response reports a total and the current URL holds a limit.
+func (current OffsetPageBase) NextPageURLs(n int) ([]string, error) {
+	count, err := current.itemCount()
+	if err != nil || count == 0 {
+		return nil, err
+	}
+
+	currentURL := current.URL
+	q := currentURL.Query()
+
+	offsetParam := current.OffsetParam
+	if offsetParam == "" {
+		offsetParam = "offset"
+	}
+	limitParam := current.LimitParam
+	if limitParam == "" {
+		limitParam = "limit"
+	}
+
+	offset := 0
+	if current.PageNumbers {
+		offset = current.FirstPage
+	}
+	if v := q.Get(offsetParam); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil {
+			return nil, err
+		}
+	}
+	limit := 0
+	if v := q.Get(limitParam); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			return nil, err
+		}
+	}
+
+	// consumed is the number of items up to and including this page, and
+	// step is how far OffsetParam advances with each full page.
+	next, consumed, step := offset+count, offset+count, limit
+	if current.PageNumbers {
+		size := limit
+		if size == 0 {
+			size = count
+		}
+		next, consumed, step = offset+1, (offset-current.FirstPage)*size+count, 1
+	}
+
+	total, hasTotal := current.total()
+	if hasTotal {
+		if consumed >= total {
+			return nil, nil
+		}
+	} else if limit == 0 || count != limit {
+		// A short page is the last one, and a longer one means the
+		// service ignores the limit.
+		return nil, nil
+	}
+
+	var urls []string
+	for len(urls) < n {
+		q.Set(offsetParam, strconv.Itoa(next))
+		currentURL.RawQuery = q.Encode()
+		urls = append(urls, currentURL.String())
+
+		consumed += limit
+		if !hasTotal || limit == 0 || consumed >= total {
+			break
+		}
+		next += step
+	}
+
+	return urls, nil
+}
+
+// IsEmpty satisifies the IsEmpty method of the Page interface
+func (current OffsetPageBase) IsEmpty() (bool, error) {
+	count, err := current.itemCount()
+	return count == 0, err
+}
+
+// GetBody returns the offset page's body. This method is needed to satisfy the
+// Page interface.
+func (current OffsetPageBase) GetBody() interface{} {
+	return current.Body
+}
+
+// itemCount returns the number of items on the page.
+func (current OffsetPageBase) itemCount() (int, error) {
+	switch b := current.Body.(type) {
+	case []interface{}:
+		return len(b), nil
+	case map[string]interface{}:
+		if current.ItemsKey != "" {
+			items, _ := b[current.ItemsKey].([]interface{})
+			return len(items), nil
+		}
+		for _, v := range b {
+			if items, ok := v.([]interface{}); ok {
+				return len(items), nil
+			}
+		}
+		return 0, nil
+	}
+	err := golangsdk.ErrUnexpectedType{}
+	err.Expected = "map[string]interface{}/[]interface{}"
+	err.Actual = fmt.Sprintf("%v", reflect.TypeOf(current.Body))
+	return 0, err
+}
+
+// total returns the total number of items reported by the response, if any.
+func (current OffsetPageBase) total() (int, bool) {
+	key := current.TotalKey
+	if key == "" {
+		key = "total"
+	}
+	b, ok := current.Body.(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+	total, ok := b[key].(float64)
+	return int(total), ok
+}
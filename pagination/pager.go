@@ -1,6 +1,7 @@
 package pagination
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -45,6 +46,10 @@ type Pager struct {
 
 	// Headers supplies additional HTTP headers to populate on each paged request.
 	Headers map[string]string
+
+	// ctx is the context each paged request is bound to. A nil ctx means
+	// context.Background().
+	ctx context.Context
 }
 
 // NewPager constructs a manually-configured pager.
@@ -64,11 +69,31 @@ func (p Pager) WithPageCreator(createPage func(r PageResult) Page) Pager {
 		client:     p.client,
 		initialURL: p.initialURL,
 		createPage: createPage,
+		ctx:        p.ctx,
 	}
 }
 
+// WithContext returns a new Pager whose page requests are bound to ctx.
+// Cancelling ctx aborts the page request in flight and stops EachPage and
+// AllPages from fetching further pages.
+func (p Pager) WithContext(ctx context.Context) Pager {
+	p.ctx = ctx
+	return p
+}
+
+func (p Pager) context() context.Context {
+	if p.ctx == nil {
+		return context.Background()
+	}
+	return p.ctx
+}
+
 func (p Pager) fetchNextPage(url string) (Page, error) {
-	resp, err := Request(p.client, p.Headers, url)
+	if err := p.context().Err(); err != nil {
+		return nil, err
+	}
+
+	resp, err := RequestWithContext(p.context(), p.client, p.Headers, url)
 	if err != nil {
 		return nil, err
 	}
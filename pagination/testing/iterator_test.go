@@ -0,0 +1,150 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/pagination"
+	"github.com/huaweicloud/golangsdk/testhelper"
+)
+
+func extractOffsetItems(page pagination.Page) (interface{}, error) {
+	return ExtractOffsetInts(page)
+}
+
+func collectItems(t *testing.T, it *pagination.Iterator) []int {
+	defer it.Close()
+
+	var items []int
+	for it.Next() {
+		items = append(items, it.Item().(int))
+	}
+	testhelper.AssertNoErr(t, it.Err())
+	return items
+}
+
+// countRequests counts the requests served by handler by offset.
+type countRequests struct {
+	sync.Mutex
+	offsets map[string]int
+}
+
+func (c *countRequests) wrap(handler http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c.Lock()
+		c.offsets[r.URL.Query().Get("offset")]++
+		c.Unlock()
+		handler.ServeHTTP(w, r)
+	}
+}
+
+func (c *countRequests) total() int {
+	c.Lock()
+	defer c.Unlock()
+	n := 0
+	for _, count := range c.offsets {
+		n += count
+	}
+	return n
+}
+
+func handleLargeOffsetPages(size int) *countRequests {
+	counter := &countRequests{offsets: make(map[string]int)}
+	testhelper.Mux.HandleFunc("/page", counter.wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		ints := []int{}
+		for i := offset; i < offset+limit && i < size; i++ {
+			ints = append(ints, i+1)
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{ "ints": %s, "total": %d }`, formatInts(ints), size)
+	})))
+	return counter
+}
+
+func TestIteratorOffsetPrefetch(t *testing.T) {
+	testhelper.SetupHTTP()
+	defer testhelper.TeardownHTTP()
+	counter := handleLargeOffsetPages(20)
+
+	pager := createOffsetPaged("/page?limit=3", pagination.OffsetPageBase{})
+	items := collectItems(t, pager.Iterator(extractOffsetItems, 3))
+
+	expected := make([]int, 20)
+	for i := range expected {
+		expected[i] = i + 1
+	}
+	testhelper.CheckDeepEquals(t, expected, items)
+
+	// Each page is fetched exactly once.
+	testhelper.CheckEquals(t, 7, counter.total())
+	for offset, count := range counter.offsets {
+		testhelper.CheckEquals(t, 1, count)
+		if offset != "" {
+			n, _ := strconv.Atoi(offset)
+			testhelper.CheckEquals(t, 0, n%3)
+		}
+	}
+}
+
+func TestIteratorEarlyExit(t *testing.T) {
+	testhelper.SetupHTTP()
+	defer testhelper.TeardownHTTP()
+	counter := handleLargeOffsetPages(20)
+
+	pager := createOffsetPaged("/page?limit=3", pagination.OffsetPageBase{})
+	it := pager.Iterator(extractOffsetItems, 0)
+	testhelper.AssertEquals(t, true, it.Next())
+	testhelper.CheckEquals(t, 1, it.Item().(int))
+	it.Close()
+
+	testhelper.CheckEquals(t, false, it.Next())
+	testhelper.AssertNoErr(t, it.Err())
+
+	// Without prefetching, only the first page was requested.
+	testhelper.CheckEquals(t, 1, counter.total())
+}
+
+func TestIteratorLinked(t *testing.T) {
+	pager := createLinked(t)
+	defer testhelper.TeardownHTTP()
+
+	it := pager.Iterator(func(page pagination.Page) (interface{}, error) {
+		return ExtractLinkedInts(page)
+	}, 2)
+	testhelper.CheckDeepEquals(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, collectItems(t, it))
+}
+
+func TestIteratorError(t *testing.T) {
+	testhelper.SetupHTTP()
+	defer testhelper.TeardownHTTP()
+
+	testhelper.Mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("offset") == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{ "ints": [1, 2], "total": 6 }`)
+	})
+
+	pager := createOffsetPaged("/page?limit=2", pagination.OffsetPageBase{})
+	it := pager.Iterator(extractOffsetItems, 2)
+	defer it.Close()
+
+	var items []int
+	for it.Next() {
+		items = append(items, it.Item().(int))
+	}
+	testhelper.CheckDeepEquals(t, []int{1, 2}, items)
+
+	_, ok := it.Err().(golangsdk.ErrDefault500)
+	testhelper.CheckEquals(t, true, ok)
+	testhelper.CheckEquals(t, false, it.Next())
+}
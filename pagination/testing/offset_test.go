@@ -0,0 +1,161 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/huaweicloud/golangsdk/pagination"
+	"github.com/huaweicloud/golangsdk/testhelper"
+)
+
+// OffsetPager sample and test cases.
+
+type OffsetPageResult struct {
+	pagination.OffsetPageBase
+}
+
+func ExtractOffsetInts(r pagination.Page) ([]int, error) {
+	var s struct {
+		Ints []int `json:"ints"`
+	}
+	err := (r.(OffsetPageResult)).ExtractInto(&s)
+	return s.Ints, err
+}
+
+// handleOffsetPages serves the integers 1 to 7, three at a time by default,
+// starting at the item or page held by offsetParam.
+func handleOffsetPages(t *testing.T, offsetParam string, pageNumbers, withTotal bool) {
+	ints := []int{1, 2, 3, 4, 5, 6, 7}
+
+	testhelper.Mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		limit := 3
+		if v := r.URL.Query().Get("limit"); v != "" {
+			limit, _ = strconv.Atoi(v)
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get(offsetParam))
+		if pageNumbers {
+			if offset == 0 {
+				offset = 1
+			}
+			offset = (offset - 1) * limit
+		}
+		if offset > len(ints) {
+			t.Errorf("Request past the end of the collection: %s", r.URL.RawQuery)
+			offset = len(ints)
+		}
+		end := offset + limit
+		if end > len(ints) {
+			end = len(ints)
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		body := fmt.Sprintf(`"ints": %s`, formatInts(ints[offset:end]))
+		if withTotal {
+			body += fmt.Sprintf(`, "total": %d`, len(ints))
+		}
+		fmt.Fprintf(w, "{ %s }", body)
+	})
+}
+
+func formatInts(ints []int) string {
+	s := "["
+	for i, n := range ints {
+		if i > 0 {
+			s += ", "
+		}
+		s += strconv.Itoa(n)
+	}
+	return s + "]"
+}
+
+func createOffsetPaged(url string, base pagination.OffsetPageBase) pagination.Pager {
+	createPage := func(r pagination.PageResult) pagination.Page {
+		p := base
+		p.PageResult = r
+		return OffsetPageResult{p}
+	}
+
+	return pagination.NewPager(createClient(), testhelper.Server.URL+url, createPage)
+}
+
+func collectOffsetPages(t *testing.T, pager pagination.Pager) [][]int {
+	var pages [][]int
+	err := pager.EachPage(func(page pagination.Page) (bool, error) {
+		actual, err := ExtractOffsetInts(page)
+		if err != nil {
+			return false, err
+		}
+		pages = append(pages, actual)
+		return true, nil
+	})
+	testhelper.AssertNoErr(t, err)
+	return pages
+}
+
+func TestEnumerateOffsetWithTotal(t *testing.T) {
+	testhelper.SetupHTTP()
+	defer testhelper.TeardownHTTP()
+	handleOffsetPages(t, "start_number", false, true)
+
+	pager := createOffsetPaged("/page", pagination.OffsetPageBase{OffsetParam: "start_number"})
+
+	expected := [][]int{{1, 2, 3}, {4, 5, 6}, {7}}
+	testhelper.CheckDeepEquals(t, expected, collectOffsetPages(t, pager))
+}
+
+func TestEnumerateOffsetWithoutTotal(t *testing.T) {
+	testhelper.SetupHTTP()
+	defer testhelper.TeardownHTTP()
+	handleOffsetPages(t, "offset", false, false)
+
+	// Without a total, the short page ends the collection.
+	pager := createOffsetPaged("/page?limit=2", pagination.OffsetPageBase{})
+	expected := [][]int{{1, 2}, {3, 4}, {5, 6}, {7}}
+	testhelper.CheckDeepEquals(t, expected, collectOffsetPages(t, pager))
+
+	// Without a total or a limit, there is no way to tell where the
+	// collection ends.
+	pager = createOffsetPaged("/page", pagination.OffsetPageBase{})
+	expected = [][]int{{1, 2, 3}}
+	testhelper.CheckDeepEquals(t, expected, collectOffsetPages(t, pager))
+}
+
+func TestEnumerateOffsetExactEnd(t *testing.T) {
+	testhelper.SetupHTTP()
+	defer testhelper.TeardownHTTP()
+	handleOffsetPages(t, "offset", false, true)
+
+	pager := createOffsetPaged("/page?limit=7", pagination.OffsetPageBase{})
+	expected := [][]int{{1, 2, 3, 4, 5, 6, 7}}
+	testhelper.CheckDeepEquals(t, expected, collectOffsetPages(t, pager))
+}
+
+func TestEnumerateOffsetPageNumbers(t *testing.T) {
+	testhelper.SetupHTTP()
+	defer testhelper.TeardownHTTP()
+	handleOffsetPages(t, "page", true, true)
+
+	pager := createOffsetPaged("/page?limit=4", pagination.OffsetPageBase{
+		OffsetParam: "page",
+		PageNumbers: true,
+		FirstPage:   1,
+	})
+	expected := [][]int{{1, 2, 3, 4}, {5, 6, 7}}
+	testhelper.CheckDeepEquals(t, expected, collectOffsetPages(t, pager))
+}
+
+func TestAllPagesOffset(t *testing.T) {
+	testhelper.SetupHTTP()
+	defer testhelper.TeardownHTTP()
+	handleOffsetPages(t, "offset", false, true)
+
+	pager := createOffsetPaged("/page", pagination.OffsetPageBase{})
+	page, err := pager.AllPages()
+	testhelper.AssertNoErr(t, err)
+
+	actual, err := ExtractOffsetInts(page)
+	testhelper.AssertNoErr(t, err)
+	testhelper.CheckDeepEquals(t, []int{1, 2, 3, 4, 5, 6, 7}, actual)
+}
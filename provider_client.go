@@ -2,12 +2,17 @@ package golangsdk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/huaweicloud/golangsdk/auth/aksk"
+	"github.com/huaweicloud/golangsdk/auth/credentials"
 )
 
 // DefaultUserAgent is the default User-Agent string set in the request header.
@@ -56,9 +61,43 @@ type ProviderClient struct {
 	// To safely read or write this value, call `Token` or `SetToken`, respectively
 	TokenID string
 
+	// TokenExpiresAt is the expiry of the token in TokenID, or the zero time
+	// if it is unknown. To safely read or write this value, call
+	// `TokenExpiry` or `SetTokenExpiry`, respectively.
+	TokenExpiresAt time.Time
+
+	// TokenRefreshWindow is how long before TokenExpiresAt the token is
+	// refreshed, ahead of the request that would otherwise fail with a 401.
+	// Zero means DefaultTokenRefreshWindow; a negative value refreshes the
+	// token only once it has expired. Tokens obtained by re-authenticating
+	// that don't outlive the window are refreshed halfway through their
+	// lifetime instead.
+	TokenRefreshWindow time.Duration
+
+	// TokenCache, if set, is consulted before requesting a new token from the
+	// identity service, and stores the tokens issued to this client so that
+	// other clients and processes can reuse them until they expire.
+	TokenCache TokenCache
+
+	// TokenCacheSecret keys the HMAC-SHA256 of the password that the keys of
+	// TokenCache hold in place of the password. Clients must share it to
+	// reuse each other's cached tokens, including across processes. When
+	// empty, a random secret generated once per process is used.
+	TokenCacheSecret []byte
+
 	// ProjectID is the ID of project to which User is authorized.
 	ProjectID string
 
+	// AKSKAuthOptions holds the access and secret keys used to sign every
+	// request. When AccessKey is set, requests are signed with AK/SK instead
+	// of carrying an X-Auth-Token header.
+	AKSKAuthOptions AKSKAuthOptions
+
+	// Credentials, if set, provides the access and secret keys requests are
+	// signed with, in place of those in AKSKAuthOptions. They are retrieved
+	// again before temporary credentials expire.
+	Credentials *credentials.Credentials
+
 	// EndpointLocator describes how this provider discovers the endpoints for
 	// its constituent services.
 	EndpointLocator EndpointLocator
@@ -66,6 +105,11 @@ type ProviderClient struct {
 	// HTTPClient allows users to interject arbitrary http, https, or other transit behaviors.
 	HTTPClient http.Client
 
+	// DisableKeepAlives closes the connection after every request instead of
+	// returning it to the pool of the HTTPClient's transport. Connections are
+	// reused by default; use ConfigureConnectionPool to tune the pool.
+	DisableKeepAlives bool
+
 	// UserAgent represents the User-Agent header in the HTTP request.
 	UserAgent UserAgent
 
@@ -74,8 +118,37 @@ type ProviderClient struct {
 	// authentication functions for different Identity service versions.
 	ReauthFunc func() error
 
+	// ReauthContextFunc is the context-aware counterpart of ReauthFunc. When set,
+	// it is preferred over ReauthFunc and receives the context of the request
+	// that triggered the re-authentication.
+	ReauthContextFunc func(ctx context.Context) error
+
+	// RetryPolicy, if set, retries requests that fail with a transient error
+	// such as a 429 or 503 response.
+	RetryPolicy *RetryPolicy
+
+	// Interceptors is the ordered chain of handlers every API call goes
+	// through. Use UseInterceptors to add to it.
+	Interceptors []Interceptor
+
+	// RateLimiter, if set, delays requests that would exceed the rates of
+	// its rules until they can be sent.
+	RateLimiter *RateLimiter
+
+	// Instrumentation, if set, observes every API call to record metrics or
+	// traces.
+	Instrumentation Instrumentation
+
+	// Logger, if set, receives a debug entry for every HTTP request with
+	// secrets redacted. No debug output is written when it is nil.
+	Logger Logger
+
 	mut *sync.RWMutex
 
+	// tokenIssuedAt is when lockedReauth obtained the current token, or the
+	// zero time if it didn't. It is guarded by mut.
+	tokenIssuedAt time.Time
+
 	reauthmut *reauthlock
 }
 
@@ -158,6 +231,52 @@ var applicationJSON = "application/json"
 // Request performs an HTTP request using the ProviderClient's current HTTPClient. An authentication
 // header will automatically be provided.
 func (client *ProviderClient) Request(method, url string, options *RequestOpts) (*http.Response, error) {
+	return client.RequestWithContext(context.Background(), method, url, options)
+}
+
+// RequestWithContext performs an HTTP request like Request, but binds it to ctx.
+// Cancelling ctx, or letting its deadline expire, aborts the request in flight
+// as well as any re-authentication it triggers.
+func (client *ProviderClient) RequestWithContext(ctx context.Context, method, url string, options *RequestOpts) (*http.Response, error) {
+	// Allow default OkCodes if none explicitly set
+	if options.OkCodes == nil {
+		options.OkCodes = defaultOkCodes(method)
+	}
+
+	if client.Instrumentation != nil {
+		return client.instrument(ctx, method, url, options, client.sendRequest)
+	}
+	return client.sendRequest(ctx, method, url, options)
+}
+
+// sendRequest passes a request through the interceptors of the client.
+func (client *ProviderClient) sendRequest(ctx context.Context, method, url string, options *RequestOpts) (*http.Response, error) {
+	if len(client.Interceptors) == 0 {
+		return client.retryRequest(ctx, method, url, options)
+	}
+	return client.intercept(ctx, method, url, options, client.retryRequest)
+}
+
+// retryRequest performs a request, retrying it according to the RetryPolicy
+// of the client.
+func (client *ProviderClient) retryRequest(ctx context.Context, method, url string, options *RequestOpts) (*http.Response, error) {
+	if client.RetryPolicy == nil {
+		return client.doRequest(ctx, method, url, options)
+	}
+	return client.RetryPolicy.do(ctx, method, options, func() (*http.Response, error) {
+		return client.doRequest(ctx, method, url, options)
+	})
+}
+
+// doRequest performs a single HTTP request, re-authenticating and retrying it
+// once if it fails with a 401.
+func (client *ProviderClient) doRequest(ctx context.Context, method, url string, options *RequestOpts) (*http.Response, error) {
+	if client.RateLimiter != nil {
+		if err := client.RateLimiter.Wait(ctx, serviceTypeFrom(ctx), method, url); err != nil {
+			return nil, err
+		}
+	}
+
 	var body io.Reader
 	var contentType *string
 
@@ -186,6 +305,7 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	// Populate the request headers. Apply options.MoreHeaders last, to give the caller the chance to
 	// modify or omit any header.
@@ -207,18 +327,41 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 		}
 	}
 
-	// get latest token from client
+	// Refresh the token first if it is about to expire.
+	if err := client.refreshExpiringToken(ctx); err != nil {
+		e := &ErrUnableToReauthenticate{}
+		e.ErrOriginal = err
+		return nil, e
+	}
+
+	// get latest token from client, unless options.MoreHeaders overrides it
 	for k, v := range client.AuthenticatedHeaders() {
+		if _, ok := options.MoreHeaders[k]; ok {
+			continue
+		}
 		req.Header.Set(k, v)
 	}
 
-	// Set connection parameter to close the connection immediately when we've got the response
-	req.Close = true
+	// Close the connection once the response has been read if the caller
+	// opted out of connection reuse.
+	req.Close = client.DisableKeepAlives
+
+	// Sign the request last, once every header it carries is known.
+	if client.AKSKAuthOptions.AccessKey != "" || client.Credentials != nil {
+		if err := client.signRequest(ctx, req); err != nil {
+			return nil, err
+		}
+	}
 
 	prereqtok := req.Header.Get("X-Auth-Token")
 
 	// Issue the request.
+	start := time.Now()
 	resp, err := client.HTTPClient.Do(req)
+	client.logRequest(req, options, resp, err, start)
+	if stats := client.callStatsFrom(ctx); stats != nil {
+		stats.record(req, resp)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -246,6 +389,7 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 			Expected: options.OkCodes,
 			Actual:   resp.StatusCode,
 			Body:     body,
+			APIError: ParseAPIError(resp.StatusCode, resp.Header, body),
 		}
 
 		errType := options.ErrorContext
@@ -256,22 +400,10 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 				err = error400er.Error400(respErr)
 			}
 		case http.StatusUnauthorized:
-			if client.ReauthFunc != nil {
-				if client.mut != nil {
-					client.mut.Lock()
-					client.reauthmut.Lock()
-					client.reauthmut.reauthing = true
-					client.reauthmut.Unlock()
-					if curtok := client.TokenID; curtok == prereqtok {
-						err = client.ReauthFunc()
-					}
-					client.reauthmut.Lock()
-					client.reauthmut.reauthing = false
-					client.reauthmut.Unlock()
-					client.mut.Unlock()
-				} else {
-					err = client.ReauthFunc()
-				}
+			if client.ReauthFunc != nil || client.ReauthContextFunc != nil {
+				err = client.lockedReauth(ctx, func() bool {
+					return client.mut == nil || client.TokenID == prereqtok
+				})
 				if err != nil {
 					e := &ErrUnableToReauthenticate{}
 					e.ErrOriginal = respErr
@@ -282,7 +414,7 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 						seeker.Seek(0, 0)
 					}
 				}
-				resp, err = client.Request(method, url, options)
+				resp, err = client.doRequest(ctx, method, url, options)
 				if err != nil {
 					switch err.(type) {
 					case *ErrUnexpectedResponseCode:
@@ -340,17 +472,57 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 		return resp, err
 	}
 
-	// Parse the response body as JSON, if requested to do so.
+	// Parse the response body as JSON, if requested to do so. Whatever the
+	// decoder leaves unread is drained so the connection can be reused.
 	if options.JSONResponse != nil {
 		defer resp.Body.Close()
 		if err := json.NewDecoder(resp.Body).Decode(options.JSONResponse); err != nil {
 			return nil, err
 		}
+		io.Copy(ioutil.Discard, resp.Body)
 	}
 
 	return resp, nil
 }
 
+// signRequest adds the project, domain and security token headers to req and
+// signs it with the client's AK/SK.
+func (client *ProviderClient) signRequest(ctx context.Context, req *http.Request) error {
+	opts := client.AKSKAuthOptions
+	if client.Credentials != nil {
+		v, err := client.Credentials.Get(ctx)
+		if err != nil {
+			return err
+		}
+		opts.AccessKey, opts.SecretKey, opts.SecurityToken = v.AccessKey, v.SecretKey, v.SecurityToken
+	}
+	if opts.ProjectId != "" {
+		req.Header.Set("X-Project-Id", opts.ProjectId)
+	}
+	if opts.DomainID != "" {
+		req.Header.Set("X-Domain-Id", opts.DomainID)
+	}
+	if opts.SecurityToken != "" {
+		req.Header.Set("X-Security-Token", opts.SecurityToken)
+	}
+	return aksk.Sign(req, aksk.SignOptions{
+		AccessKey: opts.AccessKey,
+		SecretKey: opts.SecretKey,
+	})
+}
+
+// reauth re-authenticates the client, preferring ReauthContextFunc over
+// ReauthFunc when both are set.
+func (client *ProviderClient) reauth(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if client.ReauthContextFunc != nil {
+		return client.ReauthContextFunc(ctx)
+	}
+	return client.ReauthFunc()
+}
+
 func defaultOkCodes(method string) []int {
 	switch {
 	case method == "GET":
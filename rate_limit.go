@@ -0,0 +1,271 @@
+package golangsdk
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimitRule limits the rate of the requests it matches with a token
+// bucket: up to Burst requests are sent at once, and the bucket refills at
+// Rate requests per second. A request matches when it satisfies every
+// criterion that is set; a rule without criteria matches all requests.
+type RateLimitRule struct {
+	// ServiceType matches the requests made through a ServiceClient of this
+	// type, such as "compute" or "identity".
+	ServiceType string
+
+	// URLPrefix matches the requests whose URL starts with it.
+	URLPrefix string
+
+	// Methods matches the requests with one of these HTTP methods, such as
+	// "POST". All methods match if it is empty.
+	Methods []string
+
+	// Rate is the sustained number of requests per second. It must be
+	// positive.
+	Rate float64
+
+	// Burst is the number of requests that can be sent at once. Values below
+	// 1 mean 1.
+	Burst int
+}
+
+func (r RateLimitRule) matches(serviceType, method, url string) bool {
+	if r.ServiceType != "" && r.ServiceType != serviceType {
+		return false
+	}
+	if r.URLPrefix != "" && !strings.HasPrefix(url, r.URLPrefix) {
+		return false
+	}
+	if len(r.Methods) == 0 {
+		return true
+	}
+	for _, m := range r.Methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// RateLimitStats describes the requests a RateLimitRule has limited.
+type RateLimitStats struct {
+	// Rule is the rule these statistics belong to.
+	Rule RateLimitRule
+
+	// Requests is the number of requests the rule has let through.
+	Requests int64
+
+	// Delayed is the number of those requests that had to wait.
+	Delayed int64
+
+	// Waiting is the number of requests currently waiting.
+	Waiting int
+
+	// TotalWait is the time the requests have waited in total, and MaxWait
+	// the longest a single request has waited.
+	TotalWait time.Duration
+	MaxWait   time.Duration
+
+	// CurrentWait is how long a request matching the rule would wait if it
+	// were sent now.
+	CurrentWait time.Duration
+}
+
+// RateLimiter blocks the requests of a ProviderClient so that they stay
+// within the rates of its rules, instead of letting the services reject them
+// with 429 responses. A request waits for every rule it matches. Set it on
+// ProviderClient.RateLimiter; one RateLimiter may be shared by several
+// clients whose requests count against the same quotas.
+//
+// A RateLimiter is safe for concurrent use by multiple goroutines.
+type RateLimiter struct {
+	buckets []*tokenBucket
+}
+
+// NewRateLimiter returns a RateLimiter applying rules.
+func NewRateLimiter(rules ...RateLimitRule) (*RateLimiter, error) {
+	l := &RateLimiter{}
+	for _, rule := range rules {
+		if rule.Rate <= 0 {
+			err := ErrInvalidInput{}
+			err.Argument = "RateLimitRule.Rate"
+			err.Value = rule.Rate
+			return nil, err
+		}
+		burst := float64(rule.Burst)
+		if burst < 1 {
+			burst = 1
+		}
+		l.buckets = append(l.buckets, &tokenBucket{
+			rule:   rule,
+			burst:  burst,
+			tokens: burst,
+		})
+	}
+	return l, nil
+}
+
+// Wait blocks until a request of method to url, made through a
+// ServiceClient of type serviceType, can be sent. It returns the error of ctx
+// if ctx ends first, in which case the request must not be sent.
+func (l *RateLimiter) Wait(ctx context.Context, serviceType, method, url string) error {
+	now := time.Now()
+
+	var reserved []*tokenBucket
+	var delay time.Duration
+	for _, b := range l.buckets {
+		if !b.rule.matches(serviceType, method, url) {
+			continue
+		}
+		if d := b.reserve(now); d > delay {
+			delay = d
+		}
+		reserved = append(reserved, b)
+	}
+	if delay == 0 {
+		for _, b := range reserved {
+			b.admit(0)
+		}
+		return nil
+	}
+
+	for _, b := range reserved {
+		b.wait(1)
+	}
+	defer func() {
+		for _, b := range reserved {
+			b.wait(-1)
+		}
+	}()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		for _, b := range reserved {
+			b.admit(delay)
+		}
+		return nil
+	case <-ctx.Done():
+		for _, b := range reserved {
+			b.cancel(time.Now())
+		}
+		return ctx.Err()
+	}
+}
+
+// Stats returns the statistics of each rule, in the order of the rules.
+func (l *RateLimiter) Stats() []RateLimitStats {
+	now := time.Now()
+	stats := make([]RateLimitStats, len(l.buckets))
+	for i, b := range l.buckets {
+		stats[i] = b.stats(now)
+	}
+	return stats
+}
+
+// tokenBucket is the state of a RateLimitRule. tokens goes negative when
+// requests have reserved tokens that are not refilled yet.
+type tokenBucket struct {
+	rule  RateLimitRule
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+	stat   RateLimitStats
+}
+
+// refill adds the tokens accrued since the last refill.
+func (b *tokenBucket) refill(now time.Time) {
+	if !b.last.IsZero() && now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.rule.Rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	if now.After(b.last) {
+		b.last = now
+	}
+}
+
+// delay returns how long until the bucket holds a token.
+func (b *tokenBucket) delay() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rule.Rate * float64(time.Second))
+}
+
+// reserve takes a token and returns how long to wait before it is
+// available.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(now)
+	d := b.delay()
+	b.tokens--
+	return d
+}
+
+// cancel returns a reserved token.
+func (b *tokenBucket) cancel(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(now)
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+func (b *tokenBucket) wait(n int) {
+	b.mu.Lock()
+	b.stat.Waiting += n
+	b.mu.Unlock()
+}
+
+// admit records a request let through after waiting for d.
+func (b *tokenBucket) admit(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stat.Requests++
+	if d > 0 {
+		b.stat.Delayed++
+		b.stat.TotalWait += d
+		if d > b.stat.MaxWait {
+			b.stat.MaxWait = d
+		}
+	}
+}
+
+func (b *tokenBucket) stats(now time.Time) RateLimitStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(now)
+	s := b.stat
+	s.Rule = b.rule
+	s.CurrentWait = b.delay()
+	return s
+}
+
+type serviceTypeKey struct{}
+
+// withServiceType records the type of the ServiceClient making a request on
+// its context.
+func withServiceType(ctx context.Context, serviceType string) context.Context {
+	if serviceType == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, serviceTypeKey{}, serviceType)
+}
+
+// serviceTypeFrom returns the type of the ServiceClient making the request
+// of ctx, if any.
+func serviceTypeFrom(ctx context.Context) string {
+	serviceType, _ := ctx.Value(serviceTypeKey{}).(string)
+	return serviceType
+}
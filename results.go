@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"reflect"
 	"strconv"
@@ -33,11 +34,46 @@ type Result struct {
 	// Header contains the HTTP header structure from the original response.
 	Header http.Header
 
+	// StatusCode is the HTTP status code of the original response. It is 0
+	// when the request failed before a response was received.
+	StatusCode int
+
 	// Err is an error that occurred during the operation. It's deferred until
 	// extraction to make it easier to chain the Extract call.
 	Err error
 }
 
+// requestIDHeaders are the headers in which services return the ID of a
+// request, in order of preference.
+var requestIDHeaders = []string{"X-Request-Id", "X-Openstack-Request-Id", "X-Compute-Request-Id"}
+
+// RequestID returns the ID the service assigned to the request, which its
+// support needs to trace a call. It is empty when the response carried none.
+func (r Result) RequestID() string {
+	return requestID(r.Header)
+}
+
+// ParseResponse splits the outcome of a ServiceClient request into the
+// status code, header and error of a Result, so that request functions fill
+// them consistently:
+//
+//	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(url, &r.Body, nil))
+//
+// The status code and header are also returned for an unexpected response
+// code, so that the request ID of a failed call is known. As nobody can read
+// the body of the response anymore, it is drained and closed so that the
+// connection can be reused.
+func ParseResponse(resp *http.Response, err error) (int, http.Header, error) {
+	if resp == nil {
+		return 0, nil, err
+	}
+	if resp.Body != nil {
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	return resp.StatusCode, resp.Header, err
+}
+
 // ExtractInto allows users to provide an object into which `Extract` will extract
 // the `Result.Body`. This would be useful for OpenStack providers that have
 // different fields in the response object than OpenStack proper.
@@ -1,8 +1,10 @@
 package golangsdk
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 )
 
 type JobResponse struct {
@@ -10,6 +12,9 @@ type JobResponse struct {
 	JobID string `json:"job_id"`
 }
 
+// JobStatus is the status of an asynchronous job.
+//
+// Deprecated: use Job, which also carries sub-jobs.
 type JobStatus struct {
 	Status     string                 `json:"status"`
 	Entities   map[string]interface{} `json:"entities"`
@@ -39,40 +44,40 @@ func GetJobEndpoint(endpoint string) string {
 	return endpoint[0 : n+8]
 }
 
+// WaitForJobSuccess polls the job at uri once per second until it succeeds,
+// fails or secs seconds elapse. As it always has, it queries the job under
+// version v1.0.
+//
+// Deprecated: use a JobTracker, which supports backoff, cancellation and
+// progress callbacks.
 func WaitForJobSuccess(client *ServiceClient, uri string, secs int) error {
-	uri = strings.Replace(uri, "v1", "v1.0", 1)
-
-	return WaitFor(secs, func() (bool, error) {
-		job := new(JobStatus)
-		_, err := client.Get(GetJobEndpoint(client.Endpoint)+uri, &job, nil)
-		if err != nil {
-			return false, err
-		}
-		fmt.Printf("JobStatus: %+v.\n", job)
-
-		if job.Status == "SUCCESS" {
-			return true, nil
-		}
-		if job.Status == "FAIL" {
-			err = fmt.Errorf("Job failed with code %s: %s.\n", job.ErrorCode, job.FailReason)
-			return false, err
-		}
-
-		return false, nil
-	})
+	t := &JobTracker{
+		Client:      client,
+		MinInterval: time.Second,
+		MaxInterval: time.Second,
+		Timeout:     time.Duration(secs) * time.Second,
+		JobVersion:  "v1.0",
+	}
+	_, err := t.Wait(context.Background(), uri)
+	if e, ok := err.(ErrJobTimeout); ok {
+		e.ErrTimeOut.Info = "A timeout occurred"
+		return e.ErrTimeOut
+	}
+	return err
 }
 
+// GetJobEntity returns the entity stored under label by the successful job at
+// uri, queried under version v1.0.
+//
+// Deprecated: use JobTracker.Get and Job.ExtractEntity.
 func GetJobEntity(client *ServiceClient, uri string, label string) (interface{}, error) {
-	uri = strings.Replace(uri, "v1", "v1.0", 1)
-
-	job := new(JobStatus)
-	_, err := client.Get(GetJobEndpoint(client.Endpoint)+uri, &job, nil)
+	t := &JobTracker{Client: client, JobVersion: "v1.0"}
+	job, err := t.Get(context.Background(), uri)
 	if err != nil {
 		return nil, err
 	}
-	fmt.Printf("JobStatus: %+v.\n", job)
 
-	if job.Status == "SUCCESS" {
+	if job.Status == JobStatusSuccess {
 		if e := job.Entities[label]; e != nil {
 			return e, nil
 		}
@@ -0,0 +1,181 @@
+package golangsdk
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultRetryMinBackoff is the delay before the first retry when a
+	// RetryPolicy does not set MinBackoff.
+	DefaultRetryMinBackoff = 500 * time.Millisecond
+
+	// DefaultRetryMaxBackoff is the longest computed delay between retries when
+	// a RetryPolicy does not set MaxBackoff.
+	DefaultRetryMaxBackoff = 30 * time.Second
+)
+
+// DefaultRetryStatusCodes are the HTTP status codes retried when a RetryPolicy
+// does not set RetryStatusCodes.
+var DefaultRetryStatusCodes = []int{http.StatusTooManyRequests, http.StatusServiceUnavailable}
+
+// RetryPolicy describes how a ProviderClient retries requests that fail with a
+// transient error. Set it on ProviderClient.RetryPolicy; a nil policy disables
+// retries other than the re-authentication that follows a 401.
+//
+// Only idempotent methods (GET, HEAD, OPTIONS, PUT and DELETE) are retried
+// unless RetryNonIdempotent is set. A request whose RawBody cannot be rewound
+// (it does not implement io.Seeker) is never retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// A value of 0 or 1 disables retries.
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry. Each later retry doubles
+	// the delay, up to MaxBackoff.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the computed delay between retries.
+	MaxBackoff time.Duration
+
+	// NoJitter disables the randomization of the computed delay. By default,
+	// each delay is a random value between half and all of the computed delay.
+	NoJitter bool
+
+	// IgnoreRetryAfter disables honoring the Retry-After header of a response.
+	// By default, a Retry-After value replaces the computed delay.
+	IgnoreRetryAfter bool
+
+	// RetryStatusCodes lists the HTTP status codes that can be retried.
+	// DefaultRetryStatusCodes is used if it is nil.
+	RetryStatusCodes []int
+
+	// RetryNetworkErrors allows retrying requests that failed before a response
+	// was received, such as connection resets or timeouts.
+	RetryNetworkErrors bool
+
+	// RetryNonIdempotent allows retrying POST and PATCH requests.
+	RetryNonIdempotent bool
+
+	// ShouldRetry, if set, replaces the status code and network error checks.
+	// It is still subject to MaxAttempts, the idempotency rule and body rewinding.
+	ShouldRetry func(resp *http.Response, err error) bool
+}
+
+// do calls fn until it succeeds, fails with an error that cannot be retried,
+// or the policy runs out of attempts.
+func (p *RetryPolicy) do(ctx context.Context, method string, options *RequestOpts, fn func() (*http.Response, error)) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := fn()
+		if err == nil || attempt >= p.MaxAttempts || !p.retryable(ctx, method, resp, err) {
+			return resp, err
+		}
+
+		if options.RawBody != nil {
+			seeker, ok := options.RawBody.(io.Seeker)
+			if !ok {
+				return resp, err
+			}
+			if _, serr := seeker.Seek(0, io.SeekStart); serr != nil {
+				return resp, err
+			}
+		}
+
+		select {
+		case <-time.After(p.delay(attempt, resp)):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (p *RetryPolicy) retryable(ctx context.Context, method string, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+	default:
+		if !p.RetryNonIdempotent {
+			return false
+		}
+	}
+
+	if p.ShouldRetry != nil {
+		return p.ShouldRetry(resp, err)
+	}
+
+	if resp == nil {
+		_, isNetErr := err.(*url.Error)
+		return isNetErr && p.RetryNetworkErrors
+	}
+
+	codes := p.RetryStatusCodes
+	if codes == nil {
+		codes = DefaultRetryStatusCodes
+	}
+	for _, code := range codes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// delay returns how long to wait before the attempt that follows attempt.
+func (p *RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if !p.IgnoreRetryAfter && resp != nil {
+		if d, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+
+	min, max := p.MinBackoff, p.MaxBackoff
+	if min <= 0 {
+		min = DefaultRetryMinBackoff
+	}
+	if max <= 0 {
+		max = DefaultRetryMaxBackoff
+	}
+
+	d := min
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+
+	if !p.NoJitter && d > 1 {
+		d = d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+	}
+	return d
+}
+
+// retryAfter parses a Retry-After header value, which is either a number of
+// seconds or an HTTP date.
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
@@ -1,6 +1,7 @@
 package golangsdk
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"strings"
@@ -63,49 +64,87 @@ func (client *ServiceClient) initReqOpts(url string, JSONBody interface{}, JSONR
 	}
 }
 
+// Request performs an HTTP request like ProviderClient.Request, on behalf of
+// this service.
+func (client *ServiceClient) Request(method, url string, options *RequestOpts) (*http.Response, error) {
+	return client.RequestWithContext(context.Background(), method, url, options)
+}
+
+// RequestWithContext performs an HTTP request like
+// ProviderClient.RequestWithContext, on behalf of this service. Rules of the
+// RateLimiter that match the Type of the client apply to it.
+func (client *ServiceClient) RequestWithContext(ctx context.Context, method, url string, options *RequestOpts) (*http.Response, error) {
+	return client.ProviderClient.RequestWithContext(withServiceType(ctx, client.Type), method, url, options)
+}
+
 // Get calls `Request` with the "GET" HTTP verb.
 func (client *ServiceClient) Get(url string, JSONResponse interface{}, opts *RequestOpts) (*http.Response, error) {
+	return client.GetCtx(context.Background(), url, JSONResponse, opts)
+}
+
+// GetCtx calls `RequestWithContext` with the "GET" HTTP verb.
+func (client *ServiceClient) GetCtx(ctx context.Context, url string, JSONResponse interface{}, opts *RequestOpts) (*http.Response, error) {
 	if opts == nil {
 		opts = new(RequestOpts)
 	}
 	client.initReqOpts(url, nil, JSONResponse, opts)
-	return client.Request("GET", url, opts)
+	return client.RequestWithContext(ctx, "GET", url, opts)
 }
 
 // Post calls `Request` with the "POST" HTTP verb.
 func (client *ServiceClient) Post(url string, JSONBody interface{}, JSONResponse interface{}, opts *RequestOpts) (*http.Response, error) {
+	return client.PostCtx(context.Background(), url, JSONBody, JSONResponse, opts)
+}
+
+// PostCtx calls `RequestWithContext` with the "POST" HTTP verb.
+func (client *ServiceClient) PostCtx(ctx context.Context, url string, JSONBody interface{}, JSONResponse interface{}, opts *RequestOpts) (*http.Response, error) {
 	if opts == nil {
 		opts = new(RequestOpts)
 	}
 	client.initReqOpts(url, JSONBody, JSONResponse, opts)
-	return client.Request("POST", url, opts)
+	return client.RequestWithContext(ctx, "POST", url, opts)
 }
 
 // Put calls `Request` with the "PUT" HTTP verb.
 func (client *ServiceClient) Put(url string, JSONBody interface{}, JSONResponse interface{}, opts *RequestOpts) (*http.Response, error) {
+	return client.PutCtx(context.Background(), url, JSONBody, JSONResponse, opts)
+}
+
+// PutCtx calls `RequestWithContext` with the "PUT" HTTP verb.
+func (client *ServiceClient) PutCtx(ctx context.Context, url string, JSONBody interface{}, JSONResponse interface{}, opts *RequestOpts) (*http.Response, error) {
 	if opts == nil {
 		opts = new(RequestOpts)
 	}
 	client.initReqOpts(url, JSONBody, JSONResponse, opts)
-	return client.Request("PUT", url, opts)
+	return client.RequestWithContext(ctx, "PUT", url, opts)
 }
 
 // Patch calls `Request` with the "PATCH" HTTP verb.
 func (client *ServiceClient) Patch(url string, JSONBody interface{}, JSONResponse interface{}, opts *RequestOpts) (*http.Response, error) {
+	return client.PatchCtx(context.Background(), url, JSONBody, JSONResponse, opts)
+}
+
+// PatchCtx calls `RequestWithContext` with the "PATCH" HTTP verb.
+func (client *ServiceClient) PatchCtx(ctx context.Context, url string, JSONBody interface{}, JSONResponse interface{}, opts *RequestOpts) (*http.Response, error) {
 	if opts == nil {
 		opts = new(RequestOpts)
 	}
 	client.initReqOpts(url, JSONBody, JSONResponse, opts)
-	return client.Request("PATCH", url, opts)
+	return client.RequestWithContext(ctx, "PATCH", url, opts)
 }
 
 // Delete calls `Request` with the "DELETE" HTTP verb.
 func (client *ServiceClient) Delete(url string, opts *RequestOpts) (*http.Response, error) {
+	return client.DeleteCtx(context.Background(), url, opts)
+}
+
+// DeleteCtx calls `RequestWithContext` with the "DELETE" HTTP verb.
+func (client *ServiceClient) DeleteCtx(ctx context.Context, url string, opts *RequestOpts) (*http.Response, error) {
 	if opts == nil {
 		opts = new(RequestOpts)
 	}
 	client.initReqOpts(url, nil, nil, opts)
-	return client.Request("DELETE", url, opts)
+	return client.RequestWithContext(ctx, "DELETE", url, opts)
 }
 
 func (client *ServiceClient) setMicroversionHeader(opts *RequestOpts) {
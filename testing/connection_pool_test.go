@@ -0,0 +1,104 @@
+package testing
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+func countConnections(t *testing.T, p *golangsdk.ProviderClient, requests int) int64 {
+	var conns int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"ok": true}`)
+	}))
+	server.Config.ConnState = func(c net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt64(&conns, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	for i := 0; i < requests; i++ {
+		var body map[string]interface{}
+		_, err := p.Request("GET", server.URL, &golangsdk.RequestOpts{JSONResponse: &body})
+		th.AssertNoErr(t, err)
+	}
+	return atomic.LoadInt64(&conns)
+}
+
+func TestConnectionReuse(t *testing.T) {
+	p := new(golangsdk.ProviderClient)
+	p.HTTPClient.Transport = &http.Transport{}
+	th.AssertEquals(t, int64(1), countConnections(t, p, 5))
+}
+
+func TestConnectionReuseWithoutJSONResponse(t *testing.T) {
+	var conns int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `{"job_id": "2c9eb2c1"}`)
+	}))
+	server.Config.ConnState = func(c net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt64(&conns, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	p := new(golangsdk.ProviderClient)
+	p.HTTPClient.Transport = &http.Transport{}
+	client := &golangsdk.ServiceClient{ProviderClient: p, Endpoint: server.URL + "/"}
+
+	// ParseResponse throws the bodies of these responses away: it drains them
+	// so that each request reuses the connection of the previous one.
+	for i := 0; i < 5; i++ {
+		_, _, err := golangsdk.ParseResponse(client.Delete(client.ServiceURL("servers", "1"), nil))
+		th.AssertNoErr(t, err)
+	}
+	th.AssertEquals(t, int64(1), atomic.LoadInt64(&conns))
+
+	// The body of a response returned to the caller is left for them to read.
+	resp, err := client.Delete(client.ServiceURL("servers", "1"), nil)
+	th.AssertNoErr(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, `{"job_id": "2c9eb2c1"}`, string(body))
+}
+
+func TestDisableKeepAlives(t *testing.T) {
+	p := new(golangsdk.ProviderClient)
+	p.HTTPClient.Transport = &http.Transport{}
+	p.DisableKeepAlives = true
+	th.AssertEquals(t, int64(5), countConnections(t, p, 5))
+}
+
+func TestConfigureConnectionPool(t *testing.T) {
+	p := new(golangsdk.ProviderClient)
+	ok := p.ConfigureConnectionPool(golangsdk.ConnectionPoolOpts{
+		MaxIdleConnsPerHost: 32,
+		IdleConnTimeout:     time.Minute,
+		TLSHandshakeTimeout: 5 * time.Second,
+	})
+	th.AssertEquals(t, true, ok)
+
+	transport := p.HTTPClient.Transport.(*http.Transport)
+	th.CheckEquals(t, 32, transport.MaxIdleConnsPerHost)
+	th.CheckEquals(t, time.Minute, transport.IdleConnTimeout)
+	th.CheckEquals(t, 5*time.Second, transport.TLSHandshakeTimeout)
+	if transport == http.DefaultTransport {
+		t.Errorf("http.DefaultTransport must not be modified")
+	}
+}
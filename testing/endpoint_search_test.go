@@ -18,3 +18,23 @@ func TestApplyDefaultsToEndpointOpts(t *testing.T) {
 	expected = golangsdk.EndpointOpts{Availability: golangsdk.AvailabilityPublic, Type: "compute"}
 	th.CheckDeepEquals(t, expected, eo)
 }
+
+func TestTemplateEndpoint(t *testing.T) {
+	eo := golangsdk.EndpointOpts{
+		Region:   "cn-north-1",
+		Template: "https://{service}.{region}.{domain}",
+		Domain:   "myhuaweicloud.com",
+	}
+	url, err := eo.TemplateEndpoint("dms")
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://dms.cn-north-1.myhuaweicloud.com/", url)
+
+	eo.Domain = ""
+	_, err = eo.TemplateEndpoint("dms")
+	th.CheckDeepEquals(t, golangsdk.ErrMissingInput{Argument: "Domain"}, err)
+
+	eo.Template = "https://{service}.{region}.example.com/"
+	url, err = eo.TemplateEndpoint("dms")
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://dms.cn-north-1.example.com/", url)
+}
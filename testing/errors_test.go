@@ -0,0 +1,127 @@
+package testing
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/huaweicloud/golangsdk"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+func TestParseAPIError(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Request-Id", "req-header")
+
+	cases := []struct {
+		body     string
+		header   http.Header
+		expected golangsdk.APIError
+	}{
+		{
+			body:     `{"error_code": "VPC.0101", "error_msg": "Quota exceeded"}`,
+			header:   header,
+			expected: golangsdk.APIError{StatusCode: 400, Code: "VPC.0101", Message: "Quota exceeded", RequestID: "req-header"},
+		},
+		{
+			body:     `{"error": {"code": "APIGW.0301", "message": "Incorrect IAM authentication information"}, "request_id": "req-body"}`,
+			header:   http.Header{},
+			expected: golangsdk.APIError{StatusCode: 400, Code: "APIGW.0301", Message: "Incorrect IAM authentication information", RequestID: "req-body"},
+		},
+		{
+			body:     `{"itemNotFound": {"code": 404, "message": "Instance could not be found"}}`,
+			header:   header,
+			expected: golangsdk.APIError{StatusCode: 400, Code: "404", Message: "Instance could not be found", RequestID: "req-header"},
+		},
+		{
+			body: `<?xml version="1.0" encoding="UTF-8"?>
+<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message><RequestId>req-xml</RequestId></Error>`,
+			header:   http.Header{},
+			expected: golangsdk.APIError{StatusCode: 400, Code: "NoSuchKey", Message: "The specified key does not exist.", RequestID: "req-xml"},
+		},
+		{
+			body:     "Bad Gateway",
+			header:   header,
+			expected: golangsdk.APIError{StatusCode: 400, RequestID: "req-header"},
+		},
+	}
+
+	for _, c := range cases {
+		actual := golangsdk.ParseAPIError(400, c.header, []byte(c.body))
+		th.CheckDeepEquals(t, c.expected, *actual)
+	}
+}
+
+func TestAPIErrorFromRequest(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "1b2c3d")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"error_code": "VPC.0202", "error_msg": "Subnet not found"}`)
+	})
+
+	p := new(golangsdk.ProviderClient)
+	_, err := p.Request("GET", th.Endpoint()+"route", &golangsdk.RequestOpts{})
+
+	th.AssertEquals(t, true, errors.Is(err, golangsdk.ErrDefault404{}))
+	th.AssertEquals(t, false, errors.Is(err, golangsdk.ErrDefault400{}))
+
+	var apiErr *golangsdk.APIError
+	th.AssertEquals(t, true, errors.As(err, &apiErr))
+	th.CheckEquals(t, http.StatusNotFound, apiErr.StatusCode)
+	th.CheckEquals(t, "VPC.0202", apiErr.Code)
+	th.CheckEquals(t, "Subnet not found", apiErr.Message)
+	th.CheckEquals(t, "1b2c3d", apiErr.RequestID)
+	th.CheckEquals(t, "Request failed with HTTP response code 404: [VPC.0202] Subnet not found (request ID 1b2c3d)", apiErr.Error())
+
+	// The APIError matches the ErrDefault type of its status too.
+	th.CheckEquals(t, true, errors.Is(apiErr, golangsdk.ErrDefault404{}))
+
+	// Wrapping errors keep the chain intact.
+	wrapped := golangsdk.ErrErrorAfterReauthentication{ErrOriginal: err}
+	th.CheckEquals(t, true, errors.Is(wrapped, golangsdk.ErrDefault404{}))
+}
+
+func TestAPIErrorUnmappedStatus(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprintf(w, `{"error": {"code": "Ecs.0617", "message": "In use"}}`)
+	})
+
+	p := new(golangsdk.ProviderClient)
+	_, err := p.Request("GET", th.Endpoint()+"route", &golangsdk.RequestOpts{})
+
+	_, ok := err.(golangsdk.ErrUnexpectedResponseCode)
+	th.AssertEquals(t, true, ok)
+	th.CheckEquals(t, false, errors.Is(err, golangsdk.ErrDefault404{}))
+
+	var apiErr *golangsdk.APIError
+	th.AssertEquals(t, true, errors.As(err, &apiErr))
+	th.CheckEquals(t, "Ecs.0617", apiErr.Code)
+}
+
+func TestAPIErrorConcurrentError(t *testing.T) {
+	apiErr := &golangsdk.APIError{StatusCode: 409, Code: "Ecs.0617", Message: "In use"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			th.CheckEquals(t, "Request failed with HTTP response code 409: [Ecs.0617] In use", apiErr.Error())
+		}()
+	}
+	wg.Wait()
+	th.CheckEquals(t, "", apiErr.DefaultErrString)
+
+	apiErr.Info = "custom"
+	th.CheckEquals(t, "custom", apiErr.Error())
+}
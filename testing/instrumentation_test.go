@@ -0,0 +1,172 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+// recordingInstrumentation records the calls it observes.
+type recordingInstrumentation struct {
+	name    string
+	events  *[]string
+	started []golangsdk.APICall
+	ended   []golangsdk.APICall
+}
+
+func (r *recordingInstrumentation) StartSpan(ctx context.Context, call *golangsdk.APICall) context.Context {
+	*r.events = append(*r.events, "start "+r.name)
+	call.RequestHeader.Set("X-Span-"+r.name, "1")
+	r.started = append(r.started, *call)
+	return ctx
+}
+
+func (r *recordingInstrumentation) EndSpan(ctx context.Context, call *golangsdk.APICall) {
+	*r.events = append(*r.events, "end "+r.name)
+	r.ended = append(r.ended, *call)
+}
+
+func TestInstrumentation(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	attempts := 0
+	th.Mux.HandleFunc("/v1/0123456789abcdef0123456789abcdef/vpcs/1f0e3d2c-4b5a-6978-8796-a5b4c3d2e1f0", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Span-a", "1")
+		th.TestHeader(t, r, "X-Span-b", "1")
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"vpc": {"name": "vpc-1"}}`)
+	})
+
+	var events []string
+	a := &recordingInstrumentation{name: "a", events: &events}
+	b := &recordingInstrumentation{name: "b", events: &events}
+
+	p := new(golangsdk.ProviderClient)
+	p.RetryPolicy = &golangsdk.RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Millisecond,
+	}
+	p.Instrumentation = golangsdk.MultiInstrumentation(a, b)
+	client := &golangsdk.ServiceClient{ProviderClient: p, Endpoint: th.Endpoint(), Type: "vpc"}
+
+	var actual interface{}
+	_, err := client.Put(client.ServiceURL("v1", "0123456789abcdef0123456789abcdef", "vpcs", "1f0e3d2c-4b5a-6978-8796-a5b4c3d2e1f0"),
+		map[string]string{"name": "vpc-1"}, &actual, &golangsdk.RequestOpts{OkCodes: []int{200}})
+	th.AssertNoErr(t, err)
+
+	th.CheckDeepEquals(t, []string{"start a", "start b", "end b", "end a"}, events)
+	th.AssertEquals(t, 1, len(a.ended))
+
+	call := a.ended[0]
+	th.CheckEquals(t, "vpc", call.ServiceType)
+	th.CheckEquals(t, "PUT", call.Method)
+	th.CheckEquals(t, "PUT /v1/{id}/vpcs/{id}", call.Operation)
+	th.CheckEquals(t, http.StatusOK, call.StatusCode)
+	th.CheckEquals(t, 1, call.Retries)
+	th.CheckEquals(t, int64(2*len(`{"name":"vpc-1"}`)), call.BytesSent)
+	th.CheckEquals(t, int64(len(`{"vpc": {"name": "vpc-1"}}`)), call.BytesReceived)
+	th.AssertNoErr(t, call.Err)
+	if call.Latency <= 0 {
+		t.Fatalf("expected a positive latency, got %s", call.Latency)
+	}
+
+	// The first instrumentation only sees the fields known before the call.
+	th.CheckEquals(t, 0, a.started[0].StatusCode)
+}
+
+func TestInstrumentationError(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route/42", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	var events []string
+	i := &recordingInstrumentation{name: "i", events: &events}
+	p := new(golangsdk.ProviderClient)
+	p.Instrumentation = i
+
+	_, err := p.Request("GET", th.Endpoint()+"route/42", &golangsdk.RequestOpts{})
+	_, ok := err.(golangsdk.ErrDefault404)
+	th.AssertEquals(t, true, ok)
+
+	call := i.ended[0]
+	th.CheckEquals(t, "", call.ServiceType)
+	th.CheckEquals(t, "GET /route/{id}", call.Operation)
+	th.CheckEquals(t, http.StatusNotFound, call.StatusCode)
+	th.CheckEquals(t, 0, call.Retries)
+	th.CheckDeepEquals(t, err, call.Err)
+}
+
+// callIDInstrumentation sends a distinct X-Call-Id header with each call.
+type callIDInstrumentation struct {
+	next int64
+}
+
+func (c *callIDInstrumentation) StartSpan(ctx context.Context, call *golangsdk.APICall) context.Context {
+	call.RequestHeader.Set("X-Call-Id", strconv.FormatInt(atomic.AddInt64(&c.next, 1), 10))
+	return ctx
+}
+
+func (c *callIDInstrumentation) EndSpan(ctx context.Context, call *golangsdk.APICall) {}
+
+func TestInstrumentationSharedRequestOpts(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var mu sync.Mutex
+	seen := make(map[string]int)
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Language", "en-us")
+		mu.Lock()
+		seen[r.Header.Get("X-Call-Id")]++
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	p := new(golangsdk.ProviderClient)
+	p.Instrumentation = &callIDInstrumentation{}
+	client := &golangsdk.ServiceClient{ProviderClient: p, Endpoint: th.Endpoint()}
+
+	// Packages share options between calls, as smn does.
+	shared := golangsdk.RequestOpts{
+		OkCodes:     []int{204},
+		MoreHeaders: map[string]string{"X-Language": "en-us"},
+	}
+
+	const calls = 50
+	var wg sync.WaitGroup
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Delete(client.ServiceURL("route"), &shared)
+			th.AssertNoErr(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// Each call sent its own header, and none was left in the shared map.
+	th.CheckEquals(t, calls, len(seen))
+	for id, n := range seen {
+		if id == "" || n != 1 {
+			t.Errorf("X-Call-Id %q was sent %d times", id, n)
+		}
+	}
+	th.CheckDeepEquals(t, map[string]string{"X-Language": "en-us"}, shared.MoreHeaders)
+}
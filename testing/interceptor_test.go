@@ -0,0 +1,62 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/huaweicloud/golangsdk"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+func TestInterceptorsOrderAndHeaders(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Trace", "outer,inner")
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{}`)
+	})
+
+	var calls []string
+	p := new(golangsdk.ProviderClient)
+	p.UseInterceptors(
+		func(ctx context.Context, method, url string, opts *golangsdk.RequestOpts, next golangsdk.RequestHandler) (*http.Response, error) {
+			calls = append(calls, "outer:"+method)
+			th.CheckDeepEquals(t, []int{200}, opts.OkCodes)
+			opts.MoreHeaders = map[string]string{"X-Trace": "outer"}
+			resp, err := next(ctx, method, url, opts)
+			calls = append(calls, fmt.Sprintf("outer:%d", resp.StatusCode))
+			return resp, err
+		},
+		func(ctx context.Context, method, url string, opts *golangsdk.RequestOpts, next golangsdk.RequestHandler) (*http.Response, error) {
+			calls = append(calls, "inner:"+url[strings.LastIndex(url, "/"):])
+			opts.MoreHeaders["X-Trace"] += ",inner"
+			return next(ctx, method, url, opts)
+		},
+	)
+
+	resp, err := p.Request("GET", th.Endpoint()+"route", &golangsdk.RequestOpts{})
+	th.AssertNoErr(t, err)
+	resp.Body.Close()
+	th.CheckDeepEquals(t, []string{"outer:GET", "inner:/route", "outer:200"}, calls)
+}
+
+func TestInterceptorShortCircuit(t *testing.T) {
+	p := new(golangsdk.ProviderClient)
+	p.UseInterceptors(func(ctx context.Context, method, url string, opts *golangsdk.RequestOpts, next golangsdk.RequestHandler) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+		}, nil
+	})
+
+	resp, err := p.Request("GET", "http://unreachable.invalid/route", &golangsdk.RequestOpts{})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, http.StatusOK, resp.StatusCode)
+}
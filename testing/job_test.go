@@ -0,0 +1,169 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+	"github.com/huaweicloud/golangsdk/testhelper/client"
+)
+
+func newJobTracker() *golangsdk.JobTracker {
+	t := golangsdk.NewJobTracker(client.ServiceClient())
+	t.MinInterval = time.Millisecond
+	t.MaxInterval = 5 * time.Millisecond
+	return t
+}
+
+func TestJobTrackerWaitSuccess(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	polls := 0
+	th.Mux.HandleFunc("/v1/123/jobs/job1", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		polls++
+		status := "RUNNING"
+		if polls == 3 {
+			status = "SUCCESS"
+		}
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `
+			{
+				"job_id": "job1",
+				"job_type": "createServer",
+				"status": "%s",
+				"entities": {
+					"server_id": "srv1",
+					"sub_jobs": [
+						{"job_id": "sub1", "status": "%s", "entities": {"server_id": "srv1"}}
+					]
+				}
+			}
+		`, status, status)
+	})
+
+	var progress []string
+	tracker := newJobTracker()
+	tracker.OnProgress = func(job *golangsdk.Job) {
+		progress = append(progress, job.Status)
+	}
+
+	job, err := tracker.Wait(context.Background(), "/v1/123/jobs/job1")
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []string{"RUNNING", "RUNNING", "SUCCESS"}, progress)
+
+	var serverID string
+	th.AssertNoErr(t, job.ExtractEntity("server_id", &serverID))
+	th.CheckEquals(t, "srv1", serverID)
+
+	subJobs, err := job.AllSubJobs()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(subJobs))
+	th.CheckEquals(t, "sub1", subJobs[0].JobID)
+
+	err = job.ExtractEntity("volume_id", &serverID)
+	if _, ok := err.(golangsdk.ErrJobEntityNotFound); !ok {
+		t.Errorf("expected ErrJobEntityNotFound, got %v", err)
+	}
+}
+
+func TestJobTrackerWaitFail(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/123/jobs/job1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"job_id": "job1", "status": "FAIL", "error_code": "Ecs.0001", "fail_reason": "quota"}`)
+	})
+
+	job, err := newJobTracker().Wait(context.Background(), "/v1/123/jobs/job1")
+	e, ok := err.(golangsdk.ErrJobFailed)
+	if !ok {
+		t.Fatalf("expected ErrJobFailed, got %v", err)
+	}
+	th.CheckEquals(t, "Ecs.0001", e.Job.ErrorCode)
+	th.CheckEquals(t, "FAIL", job.Status)
+}
+
+func TestJobTrackerTimeout(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/123/jobs/job1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"job_id": "job1", "status": "RUNNING"}`)
+	})
+
+	tracker := newJobTracker()
+	tracker.Timeout = 50 * time.Millisecond
+	_, err := tracker.Wait(context.Background(), "/v1/123/jobs/job1")
+	e, ok := err.(golangsdk.ErrJobTimeout)
+	if !ok {
+		t.Fatalf("expected ErrJobTimeout, got %v", err)
+	}
+	th.CheckEquals(t, "RUNNING", e.Job.Status)
+}
+
+func TestJobTrackerCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := newJobTracker().Wait(ctx, "/v1/123/jobs/job1")
+	th.AssertEquals(t, context.Canceled, err)
+}
+
+func TestJobTrackerJobURL(t *testing.T) {
+	cases := []struct {
+		resourceBase string
+		jobVersion   string
+		uri          string
+		expected     string
+	}{
+		// ECS v1.1 and EVS v2 query the jobs under the version of their URIs.
+		{"https://ecs.example.com/v1.1/123/", "", "/v1/123/jobs/job1", "https://ecs.example.com/v1/123/jobs/job1"},
+		{"https://evs.example.com/v2/123/", "", "/v1/123/jobs/job1", "https://evs.example.com/v1/123/jobs/job1"},
+		{"https://evs.example.com/v2/123/", "", "v1/123/jobs/job1", "https://evs.example.com/v1/123/jobs/job1"},
+		{"https://nat.example.com/", "", "/v2.0/jobs/job1", "https://nat.example.com/v2.0/jobs/job1"},
+		// The path before the version is kept.
+		{"https://example.com/api/v2/123/", "", "/v1/123/jobs/job1", "https://example.com/api/v1/123/jobs/job1"},
+		// ELB v1.0 reports v1 URIs.
+		{"https://elb.example.com/v1.0/123/", "v1.0", "/v1/123/jobs/job1", "https://elb.example.com/v1.0/123/jobs/job1"},
+		{"https://elb.example.com/v1.0/123/", "v1.0", "/jobs/job1", "https://elb.example.com/jobs/job1"},
+		{"https://ecs.example.com/v1.1/123/", "", "https://other.com/v1/jobs/1", "https://other.com/v1/jobs/1"},
+	}
+	for _, c := range cases {
+		tracker := golangsdk.NewJobTracker(&golangsdk.ServiceClient{
+			ProviderClient: &golangsdk.ProviderClient{},
+			ResourceBase:   c.resourceBase,
+		})
+		tracker.JobVersion = c.jobVersion
+		actual, err := tracker.JobURL(c.uri)
+		th.AssertNoErr(t, err)
+		th.CheckEquals(t, c.expected, actual)
+	}
+}
+
+func TestWaitForJobSuccess(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1.0/123/jobs/job1", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"job_id": "job1", "status": "SUCCESS", "entities": {"volume_id": "vol1"}}`)
+	})
+
+	c := client.ServiceClient()
+	c.ResourceBase = th.Endpoint() + "v1.1/123/"
+
+	th.AssertNoErr(t, golangsdk.WaitForJobSuccess(c, "/v1/123/jobs/job1", 5))
+	entity, err := golangsdk.GetJobEntity(c, "/v1/123/jobs/job1", "volume_id")
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "vol1", entity)
+}
@@ -0,0 +1,74 @@
+package testing
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/huaweicloud/golangsdk"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+type recordingLogger struct {
+	msgs   []string
+	fields []map[string]interface{}
+}
+
+func (l *recordingLogger) Debug(msg string, fields map[string]interface{}) {
+	l.msgs = append(l.msgs, msg)
+	l.fields = append(l.fields, fields)
+}
+
+func TestLoggerRedactsSecrets(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.Header().Set("X-Subject-Token", "secret-token")
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	logger := new(recordingLogger)
+	p := &golangsdk.ProviderClient{TokenID: "secret-token", Logger: logger}
+
+	opts := &golangsdk.AuthOptions{Username: "me", Password: "secret-password", DomainName: "default"}
+	body, err := opts.ToTokenV3CreateMap(nil)
+	th.AssertNoErr(t, err)
+
+	_, err = p.Request("POST", th.Endpoint()+"route", &golangsdk.RequestOpts{JSONBody: body})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, 1, len(logger.fields))
+	fields := logger.fields[0]
+	th.CheckEquals(t, "POST", fields["method"])
+	th.CheckEquals(t, http.StatusCreated, fields["status"])
+	th.CheckEquals(t, "req-123", fields["request_id"])
+	th.CheckEquals(t, golangsdk.Redacted, fields["request_headers"].(map[string]string)["X-Auth-Token"])
+	th.CheckEquals(t, golangsdk.Redacted, fields["response_headers"].(map[string]string)["X-Subject-Token"])
+
+	if strings.Contains(fmt.Sprint(fields), "secret") {
+		t.Errorf("logged fields contain a secret: %v", fields)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	body := map[string]interface{}{
+		"credential": map[string]interface{}{
+			"access": "AK",
+			"secret": "SK",
+		},
+		"name": "visible",
+	}
+	th.CheckEquals(t, `{"credential":{"access":"***","secret":"***"},"name":"visible"}`, golangsdk.RedactBody(body))
+}
+
+func TestStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := golangsdk.NewStdLogger(log.New(&buf, "", 0))
+	logger.Debug("HTTP request", map[string]interface{}{"status": 200, "method": "GET"})
+	th.CheckEquals(t, "[DEBUG] HTTP request method=GET status=200\n", buf.String())
+}
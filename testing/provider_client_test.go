@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -119,3 +120,61 @@ func TestConcurrentReauth(t *testing.T) {
 
 	th.AssertEquals(t, 1, info.numreauths)
 }
+
+func TestRequestWithContextCancel(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	done := make(chan struct{})
+	defer close(done)
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	})
+
+	p := new(golangsdk.ProviderClient)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	_, err := p.RequestWithContext(ctx, "GET", fmt.Sprintf("%s/route", th.Endpoint()), &golangsdk.RequestOpts{})
+	if err == nil {
+		t.Fatalf("expected an error from a cancelled request")
+	}
+	th.AssertEquals(t, context.Canceled, ctx.Err())
+}
+
+func TestReauthContextFunc(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Auth-Token") != "new-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{}`)
+	})
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+	p := new(golangsdk.ProviderClient)
+	p.SetToken("old-token")
+	p.ReauthFunc = func() error {
+		t.Errorf("ReauthFunc should not be called when ReauthContextFunc is set")
+		return nil
+	}
+	p.ReauthContextFunc = func(c context.Context) error {
+		th.CheckEquals(t, "value", c.Value(ctxKey{}))
+		p.SetToken("new-token")
+		return nil
+	}
+
+	resp, err := p.RequestWithContext(ctx, "GET", fmt.Sprintf("%s/route", th.Endpoint()), &golangsdk.RequestOpts{})
+	th.AssertNoErr(t, err)
+	resp.Body.Close()
+	th.AssertEquals(t, "new-token", p.Token())
+}
@@ -0,0 +1,127 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+func handleRateLimitedRoute(counter *int) {
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		*counter++
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{}`)
+	})
+}
+
+func TestRateLimiterBurst(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	requests := 0
+	handleRateLimitedRoute(&requests)
+
+	limiter, err := golangsdk.NewRateLimiter(golangsdk.RateLimitRule{Rate: 20, Burst: 2})
+	th.AssertNoErr(t, err)
+	p := new(golangsdk.ProviderClient)
+	p.RateLimiter = limiter
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := p.Request("GET", th.Endpoint()+"route", &golangsdk.RequestOpts{})
+		th.AssertNoErr(t, err)
+		resp.Body.Close()
+	}
+	elapsed := time.Since(start)
+	th.AssertEquals(t, 3, requests)
+
+	// The burst goes through at once, the third request waits for a token.
+	if elapsed < 40*time.Millisecond {
+		t.Fatalf("expected the third request to wait, the requests took %s", elapsed)
+	}
+
+	stats := limiter.Stats()
+	th.AssertEquals(t, 1, len(stats))
+	th.CheckEquals(t, int64(3), stats[0].Requests)
+	th.CheckEquals(t, int64(1), stats[0].Delayed)
+	th.CheckEquals(t, 0, stats[0].Waiting)
+	if stats[0].TotalWait <= 0 || stats[0].TotalWait != stats[0].MaxWait {
+		t.Fatalf("unexpected wait statistics: %+v", stats[0])
+	}
+}
+
+func TestRateLimiterContext(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	requests := 0
+	handleRateLimitedRoute(&requests)
+
+	limiter, err := golangsdk.NewRateLimiter(golangsdk.RateLimitRule{Rate: 0.1})
+	th.AssertNoErr(t, err)
+	p := new(golangsdk.ProviderClient)
+	p.RateLimiter = limiter
+
+	resp, err := p.Request("GET", th.Endpoint()+"route", &golangsdk.RequestOpts{})
+	th.AssertNoErr(t, err)
+	resp.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = p.RequestWithContext(ctx, "GET", th.Endpoint()+"route", &golangsdk.RequestOpts{})
+	th.AssertEquals(t, context.DeadlineExceeded, err)
+	th.AssertEquals(t, 1, requests)
+
+	// The cancelled request gave its token back.
+	stats := limiter.Stats()
+	th.CheckEquals(t, int64(1), stats[0].Requests)
+	th.CheckEquals(t, 0, stats[0].Waiting)
+	if stats[0].CurrentWait > 10*time.Second {
+		t.Fatalf("expected a wait of at most 10s, got %s", stats[0].CurrentWait)
+	}
+}
+
+func TestRateLimiterRules(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	requests := 0
+	handleRateLimitedRoute(&requests)
+
+	limiter, err := golangsdk.NewRateLimiter(
+		golangsdk.RateLimitRule{ServiceType: "compute", Methods: []string{"POST"}, Rate: 100},
+		golangsdk.RateLimitRule{URLPrefix: th.Endpoint() + "route", Rate: 100, Burst: 10},
+		golangsdk.RateLimitRule{URLPrefix: th.Endpoint() + "other", Rate: 100},
+	)
+	th.AssertNoErr(t, err)
+	p := new(golangsdk.ProviderClient)
+	p.RateLimiter = limiter
+
+	compute := &golangsdk.ServiceClient{ProviderClient: p, Endpoint: th.Endpoint(), Type: "compute"}
+	network := &golangsdk.ServiceClient{ProviderClient: p, Endpoint: th.Endpoint(), Type: "network"}
+
+	_, err = compute.Post(compute.ServiceURL("route"), nil, nil, &golangsdk.RequestOpts{OkCodes: []int{200}})
+	th.AssertNoErr(t, err)
+	_, err = compute.Get(compute.ServiceURL("route"), nil, nil)
+	th.AssertNoErr(t, err)
+	_, err = network.Post(network.ServiceURL("route"), nil, nil, &golangsdk.RequestOpts{OkCodes: []int{200}})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 3, requests)
+
+	stats := limiter.Stats()
+	th.CheckEquals(t, int64(1), stats[0].Requests)
+	th.CheckEquals(t, int64(3), stats[1].Requests)
+	th.CheckEquals(t, int64(0), stats[2].Requests)
+}
+
+func TestRateLimiterInvalidRate(t *testing.T) {
+	_, err := golangsdk.NewRateLimiter(golangsdk.RateLimitRule{ServiceType: "compute"})
+	if _, ok := err.(golangsdk.ErrInvalidInput); !ok {
+		t.Fatalf("expected ErrInvalidInput, got %T: %v", err, err)
+	}
+}
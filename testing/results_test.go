@@ -2,6 +2,7 @@ package testing
 
 import (
 	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/huaweicloud/golangsdk"
@@ -206,3 +207,44 @@ func TestUnmarshalSliceOfNamedStructs(t *testing.T) {
 	th.AssertEquals(t, "", actual[1].TestPerson.Name)
 	th.AssertEquals(t, "", actual[1].TestPersonExt.Location)
 }
+
+func TestResultRequestID(t *testing.T) {
+	r := golangsdk.Result{Header: http.Header{}}
+	th.CheckEquals(t, "", r.RequestID())
+
+	r.Header.Set("X-Openstack-Request-Id", "req-openstack")
+	th.CheckEquals(t, "req-openstack", r.RequestID())
+
+	r.Header.Set("X-Request-Id", "req-cloud")
+	th.CheckEquals(t, "req-cloud", r.RequestID())
+}
+
+func TestParseResponse(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "5f3e")
+		w.WriteHeader(http.StatusConflict)
+	})
+
+	client := &golangsdk.ServiceClient{
+		ProviderClient: &golangsdk.ProviderClient{},
+		Endpoint:       th.Endpoint(),
+	}
+
+	// A failed request still reports its status code and request ID.
+	var r golangsdk.Result
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(client.Get(client.ServiceURL("route"), &r.Body, nil))
+	th.CheckEquals(t, http.StatusConflict, r.StatusCode)
+	th.CheckEquals(t, "5f3e", r.RequestID())
+	_, ok := r.Err.(golangsdk.ErrUnexpectedResponseCode)
+	th.CheckEquals(t, true, ok)
+
+	// No response at all leaves them empty.
+	r = golangsdk.Result{}
+	r.StatusCode, r.Header, r.Err = golangsdk.ParseResponse(nil, golangsdk.ErrTimeOut{})
+	th.CheckEquals(t, 0, r.StatusCode)
+	th.CheckEquals(t, "", r.RequestID())
+	th.CheckEquals(t, true, r.Err != nil)
+}
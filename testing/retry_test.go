@@ -0,0 +1,133 @@
+package testing
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+func TestRetryPolicyRetriesStatusCodes(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	attempts := 0
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{}`)
+	})
+
+	p := new(golangsdk.ProviderClient)
+	p.RetryPolicy = &golangsdk.RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+	}
+
+	resp, err := p.Request("GET", th.Endpoint()+"route", &golangsdk.RequestOpts{})
+	th.AssertNoErr(t, err)
+	resp.Body.Close()
+	th.AssertEquals(t, 3, attempts)
+}
+
+func TestRetryPolicyMaxAttempts(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	attempts := 0
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	p := new(golangsdk.ProviderClient)
+	p.RetryPolicy = &golangsdk.RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Millisecond,
+	}
+
+	_, err := p.Request("GET", th.Endpoint()+"route", &golangsdk.RequestOpts{})
+	if _, ok := err.(golangsdk.ErrDefault429); !ok {
+		t.Fatalf("expected ErrDefault429, got %T: %v", err, err)
+	}
+	th.AssertEquals(t, 2, attempts)
+}
+
+func TestRetryPolicyRetryAfter(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var first time.Time
+	attempts := 0
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if elapsed := time.Since(first); elapsed < time.Second {
+			t.Errorf("retried after %s, before Retry-After elapsed", elapsed)
+		}
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{}`)
+	})
+
+	p := new(golangsdk.ProviderClient)
+	p.RetryPolicy = &golangsdk.RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Millisecond,
+	}
+
+	resp, err := p.Request("GET", th.Endpoint()+"route", &golangsdk.RequestOpts{})
+	th.AssertNoErr(t, err)
+	resp.Body.Close()
+	th.AssertEquals(t, 2, attempts)
+}
+
+func TestRetryPolicyNonIdempotent(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	attempts := 0
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	p := new(golangsdk.ProviderClient)
+	p.RetryPolicy = &golangsdk.RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+	}
+
+	_, err := p.Request("POST", th.Endpoint()+"route", &golangsdk.RequestOpts{
+		RawBody: strings.NewReader("body"),
+	})
+	if _, ok := err.(golangsdk.ErrDefault503); !ok {
+		t.Fatalf("expected ErrDefault503, got %T: %v", err, err)
+	}
+	th.AssertEquals(t, 1, attempts)
+
+	attempts = 0
+	p.RetryPolicy.RetryNonIdempotent = true
+	_, err = p.Request("POST", th.Endpoint()+"route", &golangsdk.RequestOpts{
+		RawBody: bytes.NewReader([]byte("body")),
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, attempts)
+}
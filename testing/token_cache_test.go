@@ -0,0 +1,65 @@
+package testing
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+func TestFileTokenCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangsdk-tokens")
+	th.AssertNoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	cache := golangsdk.NewFileTokenCache(filepath.Join(dir, "cache"))
+
+	token, err := cache.Get("key")
+	th.AssertNoErr(t, err)
+	if token != nil {
+		t.Fatalf("Expected no token, got %+v", token)
+	}
+
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	err = cache.Set("key", &golangsdk.CachedToken{
+		ID:        "token-id",
+		ExpiresAt: expiresAt,
+		Body:      json.RawMessage(`{"token":{}}`),
+	})
+	th.AssertNoErr(t, err)
+
+	token, err = cache.Get("key")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "token-id", token.ID)
+	th.AssertEquals(t, true, expiresAt.Equal(token.ExpiresAt))
+	th.AssertJSONEquals(t, `{"token":{}}`, token.Body)
+
+	other, err := cache.Get("other-key")
+	th.AssertNoErr(t, err)
+	if other != nil {
+		t.Fatalf("Expected no token for another key, got %+v", other)
+	}
+
+	if runtime.GOOS != "windows" {
+		files, err := filepath.Glob(filepath.Join(dir, "cache", "*.json"))
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, 1, len(files))
+		info, err := os.Stat(files[0])
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	th.AssertNoErr(t, cache.Delete("key"))
+	token, err = cache.Get("key")
+	th.AssertNoErr(t, err)
+	if token != nil {
+		t.Fatalf("Expected deleted token, got %+v", token)
+	}
+	th.AssertNoErr(t, cache.Delete("key"))
+}
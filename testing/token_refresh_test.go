@@ -0,0 +1,124 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+func newRefreshingProvider(expiresIn time.Duration, reauths *int32) *golangsdk.ProviderClient {
+	p := new(golangsdk.ProviderClient)
+	p.UseTokenLock()
+	p.SetToken("old-token")
+	p.SetTokenExpiry(time.Now().Add(expiresIn))
+	p.ReauthContextFunc = func(ctx context.Context) error {
+		atomic.AddInt32(reauths, 1)
+		time.Sleep(10 * time.Millisecond)
+		p.TokenID = "new-token"
+		p.TokenExpiresAt = time.Now().Add(24 * time.Hour)
+		return nil
+	}
+	return p
+}
+
+func handleTokenRoute(t *testing.T, token string) {
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		th.CheckEquals(t, token, r.Header.Get("X-Auth-Token"))
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{}`)
+	})
+}
+
+func TestProactiveTokenRefresh(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleTokenRoute(t, "new-token")
+
+	var reauths int32
+	p := newRefreshingProvider(time.Minute, &reauths)
+
+	wg := new(sync.WaitGroup)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := p.Request("GET", fmt.Sprintf("%s/route", th.Endpoint()), &golangsdk.RequestOpts{})
+			th.CheckNoErr(t, err)
+			if resp != nil {
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	th.AssertEquals(t, int32(1), atomic.LoadInt32(&reauths))
+	th.AssertEquals(t, "new-token", p.Token())
+}
+
+func TestTokenNotRefreshedOutsideWindow(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleTokenRoute(t, "old-token")
+
+	var reauths int32
+	p := newRefreshingProvider(time.Hour, &reauths)
+	p.TokenRefreshWindow = 10 * time.Minute
+
+	resp, err := p.Request("GET", fmt.Sprintf("%s/route", th.Endpoint()), &golangsdk.RequestOpts{})
+	th.AssertNoErr(t, err)
+	resp.Body.Close()
+
+	th.AssertEquals(t, int32(0), atomic.LoadInt32(&reauths))
+}
+
+func TestStartTokenRefresh(t *testing.T) {
+	var reauths int32
+	p := newRefreshingProvider(time.Hour+50*time.Millisecond, &reauths)
+	p.TokenRefreshWindow = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p.StartTokenRefresh(ctx)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for p.Token() != "new-token" {
+		if time.Now().After(deadline) {
+			t.Fatalf("Token was not refreshed in the background")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	th.AssertEquals(t, int32(1), atomic.LoadInt32(&reauths))
+}
+
+func TestTokenRefreshShortLivedToken(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleTokenRoute(t, "new-token")
+
+	// Fresh tokens live for less than the refresh window.
+	var reauths int32
+	p := newRefreshingProvider(time.Minute, &reauths)
+	p.TokenRefreshWindow = 48 * time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p.StartTokenRefresh(ctx)
+
+	for i := 0; i < 10; i++ {
+		resp, err := p.Request("GET", fmt.Sprintf("%s/route", th.Endpoint()), &golangsdk.RequestOpts{})
+		th.AssertNoErr(t, err)
+		resp.Body.Close()
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	// The expiring token is refreshed once; the new one isn't refreshed
+	// again until halfway through its lifetime.
+	th.AssertEquals(t, int32(1), atomic.LoadInt32(&reauths))
+}
@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
@@ -53,6 +54,36 @@ func TestWaitForPredicateExceed(t *testing.T) {
 	th.AssertEquals(t, "A timeout occurred", err.Error())
 }
 
+func TestWaitForPredicateHangs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+
+	start := time.Now()
+	err := golangsdk.WaitFor(1, func() (bool, error) {
+		<-release
+		return true, nil
+	})
+	th.AssertEquals(t, "A timeout occurred", err.Error())
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("expected the timeout after 1s, returned after %s", elapsed)
+	}
+}
+
+func TestWaitForContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := golangsdk.WaitForContext(ctx, 60, func() (bool, error) {
+		t.Errorf("predicate should not be called after cancellation")
+		return false, nil
+	})
+	th.AssertEquals(t, context.Canceled, err)
+}
+
 func TestNormalizeURL(t *testing.T) {
 	urls := []string{
 		"NoSlashAtEnd",
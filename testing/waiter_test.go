@@ -0,0 +1,124 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	th "github.com/huaweicloud/golangsdk/testhelper"
+)
+
+func TestWaiterBackoff(t *testing.T) {
+	w := golangsdk.Waiter{
+		MinInterval: 10 * time.Millisecond,
+		MaxInterval: 40 * time.Millisecond,
+		NoJitter:    true,
+	}
+
+	var polls []time.Time
+	_, err := w.Wait(context.Background(), func(ctx context.Context) (bool, interface{}, error) {
+		polls = append(polls, time.Now())
+		return len(polls) == 5, nil, nil
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 5, len(polls))
+
+	// Expected delays: 10, 20, 40, 40 ms.
+	expected := []time.Duration{10, 20, 40, 40}
+	for i, d := range expected {
+		gap := polls[i+1].Sub(polls[i])
+		if gap < d*time.Millisecond {
+			t.Errorf("Poll %d came after %s, expected at least %dms", i+1, gap, d)
+		}
+	}
+}
+
+func TestWaiterTimeout(t *testing.T) {
+	w := golangsdk.Waiter{
+		Timeout:     30 * time.Millisecond,
+		MinInterval: 5 * time.Millisecond,
+		MaxInterval: 5 * time.Millisecond,
+	}
+
+	state, err := w.Wait(context.Background(), func(ctx context.Context) (bool, interface{}, error) {
+		return false, "BUILD", nil
+	})
+	th.AssertEquals(t, "BUILD", state)
+
+	timeoutErr, ok := err.(golangsdk.ErrTimeOut)
+	if !ok {
+		t.Fatalf("Expected ErrTimeOut, got %T: %v", err, err)
+	}
+	th.AssertEquals(t, 30*time.Millisecond, timeoutErr.Timeout)
+	th.AssertEquals(t, "BUILD", timeoutErr.LastState)
+}
+
+func TestWaiterContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := golangsdk.Waiter{MinInterval: time.Millisecond}
+
+	_, err := w.Wait(ctx, func(ctx context.Context) (bool, interface{}, error) {
+		cancel()
+		return false, nil, nil
+	})
+	th.AssertEquals(t, context.Canceled, err)
+}
+
+func TestWaiterError(t *testing.T) {
+	w := golangsdk.Waiter{MinInterval: time.Millisecond}
+	expected := errors.New("boom")
+
+	_, err := w.Wait(context.Background(), func(ctx context.Context) (bool, interface{}, error) {
+		return false, nil, expected
+	})
+	th.AssertEquals(t, expected, err)
+}
+
+func statusSequence(statuses ...string) golangsdk.StatusGetter {
+	i := 0
+	return func() (interface{}, string, error) {
+		s := statuses[i]
+		if i < len(statuses)-1 {
+			i++
+		}
+		return "resource-" + s, s, nil
+	}
+}
+
+func TestWaiterWaitForStatus(t *testing.T) {
+	w := golangsdk.Waiter{MinInterval: time.Millisecond, MaxInterval: time.Millisecond}
+
+	r, err := w.WaitForStatus(context.Background(), statusSequence("BUILD", "BUILD", "ACTIVE"),
+		[]string{"ACTIVE"}, []string{"BUILD"}, []string{"ERROR"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "resource-ACTIVE", r)
+}
+
+func TestWaiterWaitForStatusFailed(t *testing.T) {
+	w := golangsdk.Waiter{MinInterval: time.Millisecond, MaxInterval: time.Millisecond}
+
+	r, err := w.WaitForStatus(context.Background(), statusSequence("BUILD", "ERROR"),
+		[]string{"ACTIVE"}, nil, []string{"ERROR"})
+	th.AssertEquals(t, "resource-ERROR", r)
+
+	statusErr, ok := err.(golangsdk.ErrUnexpectedStatus)
+	if !ok {
+		t.Fatalf("Expected ErrUnexpectedStatus, got %T: %v", err, err)
+	}
+	th.AssertEquals(t, "ERROR", statusErr.Status)
+}
+
+func TestWaiterWaitForStatusUnexpected(t *testing.T) {
+	w := golangsdk.Waiter{MinInterval: time.Millisecond, MaxInterval: time.Millisecond}
+
+	_, err := w.WaitForStatus(context.Background(), statusSequence("BUILD", "DELETED"),
+		[]string{"ACTIVE"}, []string{"BUILD"}, nil)
+
+	statusErr, ok := err.(golangsdk.ErrUnexpectedStatus)
+	if !ok {
+		t.Fatalf("Expected ErrUnexpectedStatus, got %T: %v", err, err)
+	}
+	th.AssertEquals(t, "DELETED", statusErr.Status)
+}
@@ -0,0 +1,160 @@
+package golangsdk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CachedToken is a token held in a TokenCache, along with the body of the
+// response it was issued in, from which its service catalog, project and
+// user can be extracted again.
+type CachedToken struct {
+	ID        string          `json:"id"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	Body      json.RawMessage `json:"body"`
+}
+
+// TokenCache stores issued tokens so that they can be reused until they
+// expire, by later clients or by other processes. Keys are opaque strings
+// identifying the identity endpoint, user, domain and scope of a token. They
+// never hold the password, only an HMAC of it keyed with the
+// ProviderClient's TokenCacheSecret, but they are still sensitive: a backend
+// should not expose them.
+type TokenCache interface {
+	// Get returns the token stored under key, or nil if there is none.
+	Get(key string) (*CachedToken, error)
+
+	// Set stores token under key, replacing any previous one.
+	Set(key string, token *CachedToken) error
+
+	// Delete removes the token stored under key, if any.
+	Delete(key string) error
+}
+
+// FileTokenCache is a TokenCache storing each token in its own file of a
+// directory. Files are readable by their owner only, and access to them is
+// serialized with file locks so that the cache can be shared by concurrent
+// processes.
+type FileTokenCache struct {
+	// Dir is the directory holding the token files. It is created with 0700
+	// permissions if it does not exist.
+	Dir string
+}
+
+// NewFileTokenCache returns a FileTokenCache storing tokens in dir.
+func NewFileTokenCache(dir string) *FileTokenCache {
+	return &FileTokenCache{Dir: dir}
+}
+
+// path returns the file holding the token stored under key. Keys are hashed
+// so that they are safe to use as file names and do not reveal who the token
+// belongs to.
+func (c *FileTokenCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get implements TokenCache.
+func (c *FileTokenCache) Get(key string) (*CachedToken, error) {
+	path := c.path(key)
+	unlock, err := lockFile(path+".lock", false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var token CachedToken
+	if err := json.Unmarshal(b, &token); err != nil {
+		// A corrupt entry is a cache miss; it is overwritten by the next Set.
+		return nil, nil
+	}
+	return &token, nil
+}
+
+// Set implements TokenCache. The token is written to a temporary file which
+// then replaces the previous one, so that readers never see a partial write.
+func (c *FileTokenCache) Set(key string, token *CachedToken) error {
+	if err := os.MkdirAll(c.Dir, 0700); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	path := c.path(key)
+	unlock, err := lockFile(path+".lock", true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	tmp, err := ioutil.TempFile(c.Dir, ".token-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Delete implements TokenCache.
+func (c *FileTokenCache) Delete(key string) error {
+	path := c.path(key)
+	unlock, err := lockFile(path+".lock", true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// lockFile acquires a lock on the file at path, creating it if needed. The
+// lock is exclusive if exclusive is true, and shared otherwise. The returned
+// function releases it.
+func lockFile(path string, exclusive bool) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := flock(f, exclusive); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return func() {
+		funlock(f)
+		f.Close()
+	}, nil
+}
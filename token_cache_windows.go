@@ -0,0 +1,17 @@
+//go:build windows
+// +build windows
+
+package golangsdk
+
+import "os"
+
+// The standard library does not expose LockFileEx, so on Windows the
+// FileTokenCache relies on its atomic renames only.
+
+func flock(f *os.File, exclusive bool) error {
+	return nil
+}
+
+func funlock(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,209 @@
+package golangsdk
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultTokenRefreshWindow is how long before its expiry a token is
+// refreshed when ProviderClient.TokenRefreshWindow is zero.
+const DefaultTokenRefreshWindow = 5 * time.Minute
+
+// tokenRefreshRetryInterval is how long the background refresher waits
+// before trying again after a failed refresh.
+var tokenRefreshRetryInterval = 30 * time.Second
+
+// reauthContextKey marks the context passed to ReauthContextFunc, and so the
+// requests it issues.
+type reauthContextKey struct{}
+
+// TokenExpiry safely reads the expiry of the auth token from the
+// ProviderClient. It is the zero time when the expiry is unknown.
+func (client *ProviderClient) TokenExpiry() time.Time {
+	if client.mut != nil {
+		client.mut.RLock()
+		defer client.mut.RUnlock()
+	}
+	return client.TokenExpiresAt
+}
+
+// SetTokenExpiry safely sets the expiry of the auth token in the
+// ProviderClient. Applications may use this method in a custom ReauthFunc.
+func (client *ProviderClient) SetTokenExpiry(t time.Time) {
+	if client.mut != nil {
+		client.mut.Lock()
+		defer client.mut.Unlock()
+	}
+	client.TokenExpiresAt = t
+}
+
+// tokenTimes safely reads the expiry of the auth token and when it was
+// obtained by re-authenticating.
+func (client *ProviderClient) tokenTimes() (expiry, issued time.Time) {
+	if client.mut != nil {
+		client.mut.RLock()
+		defer client.mut.RUnlock()
+	}
+	return client.TokenExpiresAt, client.tokenIssuedAt
+}
+
+// StartTokenRefresh refreshes the token in the background shortly before it
+// expires, so that requests never have to wait for a refresh. It runs until
+// ctx is done. The client must have been set up with UseTokenLock and a
+// ReauthFunc or ReauthContextFunc.
+func (client *ProviderClient) StartTokenRefresh(ctx context.Context) {
+	go func() {
+		refreshed := false
+		for {
+			wait := client.tokenRefreshDelay()
+			if refreshed && wait < tokenRefreshRetryInterval {
+				// Don't re-authenticate back to back, whatever the
+				// lifetime of the new token.
+				wait = tokenRefreshRetryInterval
+			}
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			err := client.refreshExpiringToken(ctx)
+			refreshed = err == nil
+			if err != nil {
+				client.LogDebug("Background token refresh failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+				timer := time.NewTimer(tokenRefreshRetryInterval)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+			}
+		}
+	}()
+}
+
+// tokenRefreshDelay returns how long the background refresher waits before
+// the token enters its refresh window.
+func (client *ProviderClient) tokenRefreshDelay() time.Duration {
+	expiry, issued := client.tokenTimes()
+	if expiry.IsZero() {
+		return DefaultTokenRefreshWindow
+	}
+	if d := time.Until(expiry.Add(-client.refreshWindowFor(expiry, issued))); d > 0 {
+		return d
+	}
+	return 0
+}
+
+func (client *ProviderClient) tokenRefreshWindow() time.Duration {
+	if client.TokenRefreshWindow == 0 {
+		return DefaultTokenRefreshWindow
+	}
+	return client.TokenRefreshWindow
+}
+
+// refreshWindowFor returns the refresh window of a token expiring at expiry
+// and obtained at issued. A token that doesn't outlive the window would be
+// due for a refresh as soon as it is obtained: it is refreshed halfway
+// through its lifetime instead.
+func (client *ProviderClient) refreshWindowFor(expiry, issued time.Time) time.Duration {
+	window := client.tokenRefreshWindow()
+	if window < 0 {
+		window = 0
+	}
+	if !issued.IsZero() {
+		if lifetime := expiry.Sub(issued); lifetime > 0 && lifetime <= window {
+			window = lifetime / 2
+		}
+	}
+	return window
+}
+
+// tokenExpiring reports whether a token expiring at expiry and obtained at
+// issued is within its refresh window. An unknown expiry is never expiring.
+func (client *ProviderClient) tokenExpiring(expiry, issued time.Time) bool {
+	if expiry.IsZero() {
+		return false
+	}
+	return time.Until(expiry) <= client.refreshWindowFor(expiry, issued)
+}
+
+// refreshExpiringToken re-authenticates the client if its token is about to
+// expire. Concurrent callers share a single re-authentication.
+func (client *ProviderClient) refreshExpiringToken(ctx context.Context) error {
+	if client.ReauthFunc == nil && client.ReauthContextFunc == nil {
+		return nil
+	}
+	// Requests issued by the re-authentication itself must not try to
+	// refresh the token again.
+	if ctx.Value(reauthContextKey{}) != nil {
+		return nil
+	}
+	if client.reauthmut != nil {
+		client.reauthmut.RLock()
+		reauthing := client.reauthmut.reauthing
+		client.reauthmut.RUnlock()
+		if reauthing {
+			// Only a ReauthContextFunc lets requests made while
+			// re-authenticating be told apart from concurrent ones. Those
+			// wait for the new token; otherwise, fall back to retrying on
+			// a 401.
+			if client.ReauthContextFunc != nil {
+				client.mut.RLock()
+				client.mut.RUnlock()
+			}
+			return nil
+		}
+	}
+	if !client.tokenExpiring(client.tokenTimes()) {
+		return nil
+	}
+	return client.lockedReauth(ctx, func() bool {
+		return client.tokenExpiring(client.TokenExpiresAt, client.tokenIssuedAt)
+	})
+}
+
+// lockedReauth re-authenticates the client if needed returns true. With a
+// token lock, needed is evaluated with the lock held, so that only the first
+// of several concurrent callers re-authenticates. The token expiry is cleared
+// before re-authenticating; the reauth function is expected to set it again.
+func (client *ProviderClient) lockedReauth(ctx context.Context, needed func() bool) (err error) {
+	ctx = context.WithValue(ctx, reauthContextKey{}, true)
+	if client.mut == nil {
+		if needed() {
+			err = client.reauthExpiring(ctx)
+		}
+		return err
+	}
+
+	client.mut.Lock()
+	defer client.mut.Unlock()
+	client.reauthmut.Lock()
+	client.reauthmut.reauthing = true
+	client.reauthmut.Unlock()
+	if needed() {
+		err = client.reauthExpiring(ctx)
+	}
+	client.reauthmut.Lock()
+	client.reauthmut.reauthing = false
+	client.reauthmut.Unlock()
+	return err
+}
+
+// reauthExpiring re-authenticates the client, recording when the new token
+// was obtained. The token lock must be held, if any.
+func (client *ProviderClient) reauthExpiring(ctx context.Context) error {
+	client.TokenExpiresAt = time.Time{}
+	client.tokenIssuedAt = time.Time{}
+	issued := time.Now()
+	if err := client.reauth(ctx); err != nil {
+		return err
+	}
+	client.tokenIssuedAt = issued
+	return nil
+}
@@ -1,57 +1,72 @@
 package golangsdk
 
 import (
-	"fmt"
+	"context"
 	"net/url"
 	"path/filepath"
 	"strings"
 	"time"
 )
 
-// WaitFor polls a predicate function, once per second, up to a timeout limit.
+// WaitFor polls a predicate function, once per second, up to a timeout limit
+// in seconds. A negative timeout waits forever.
 // This is useful to wait for a resource to transition to a certain state.
-// To handle situations when the predicate might hang indefinitely, the
-// predicate will be prematurely cancelled after the timeout.
+// If the timeout elapses, an ErrTimeOut is returned, even if a predicate call
+// that was still running when it elapsed returns an error afterwards.
 // Resource packages will wrap this in a more convenient function that's
 // specific to a certain resource, but it can also be useful on its own.
+// Use a Waiter for backoff and sub-second intervals.
 func WaitFor(timeout int, predicate func() (bool, error)) error {
-	type WaitForResult struct {
-		Success bool
-		Error   error
-	}
-
-	start := time.Now().Unix()
+	return WaitForContext(context.Background(), timeout, predicate)
+}
 
-	for {
-		// If a timeout is set, and that's been exceeded, shut it down.
-		if timeout >= 0 && time.Now().Unix()-start >= int64(timeout) {
-			return fmt.Errorf("A timeout occurred")
-		}
+// WaitForContext is like WaitFor, but it also stops waiting as soon as ctx is
+// done, returning the context's error. A predicate call still running then is
+// left to finish in the background, and its result is ignored.
+func WaitForContext(ctx context.Context, timeout int, predicate func() (bool, error)) error {
+	// WaitFor has always reported timeouts with this message.
+	timeoutErr := ErrTimeOut{}
+	timeoutErr.Info = "A timeout occurred"
+	if timeout == 0 {
+		return timeoutErr
+	}
 
-		time.Sleep(1 * time.Second)
+	w := Waiter{
+		Delay:       1 * time.Second,
+		MinInterval: 1 * time.Second,
+		MaxInterval: 1 * time.Second,
+		NoJitter:    true,
+	}
+	if timeout > 0 {
+		w.Timeout = time.Duration(timeout) * time.Second
+	}
 
-		var result WaitForResult
-		ch := make(chan bool, 1)
+	_, err := w.Wait(ctx, func(ctx context.Context) (bool, interface{}, error) {
+		// The predicate can't be cancelled, so race it against the deadline.
+		// The channel is buffered: the goroutine exits once the call returns,
+		// even if nobody waits for it anymore.
+		ch := make(chan predicateResult, 1)
 		go func() {
-			defer close(ch)
-			satisfied, err := predicate()
-			result.Success = satisfied
-			result.Error = err
+			done, err := predicate()
+			ch <- predicateResult{done: done, err: err}
 		}()
-
 		select {
-		case <-ch:
-			if result.Error != nil {
-				return result.Error
-			}
-			if result.Success {
-				return nil
-			}
-		// If the predicate has not finished by the timeout, cancel it.
-		case <-time.After(time.Duration(timeout) * time.Second):
-			return fmt.Errorf("A timeout occurred")
+		case r := <-ch:
+			return r.done, nil, r.err
+		case <-ctx.Done():
+			return false, nil, nil
 		}
+	})
+	if e, ok := err.(ErrTimeOut); ok {
+		timeoutErr.Timeout = e.Timeout
+		return timeoutErr
 	}
+	return err
+}
+
+type predicateResult struct {
+	done bool
+	err  error
 }
 
 // NormalizeURL is an internal function to be used by provider clients.
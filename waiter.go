@@ -0,0 +1,183 @@
+package golangsdk
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+const (
+	// DefaultWaitMinInterval is the delay between the first polls of a Waiter
+	// that does not set MinInterval.
+	DefaultWaitMinInterval = 1 * time.Second
+
+	// DefaultWaitMaxInterval caps the delay between polls of a Waiter that
+	// does not set MaxInterval.
+	DefaultWaitMaxInterval = 10 * time.Second
+)
+
+// WaitFunc is polled by a Waiter. It reports whether the wait is over and the
+// state it observed, which is returned by Wait or carried by ErrTimeOut. The
+// context is cancelled when the Waiter gives up, so long-running checks
+// should pass it on to the requests they make.
+type WaitFunc func(ctx context.Context) (done bool, state interface{}, err error)
+
+// StatusGetter returns a resource and its current status. It is polled by
+// WaitForStatus.
+type StatusGetter func() (resource interface{}, status string, err error)
+
+// Waiter polls a WaitFunc with exponential backoff until it is done, fails,
+// the Timeout elapses or the context is cancelled. The zero value polls every
+// DefaultWaitMinInterval at first, doubling the delay up to
+// DefaultWaitMaxInterval, with jitter and no timeout.
+type Waiter struct {
+	// Timeout limits how long Wait polls. Zero means no limit other than the
+	// deadline of the context passed to Wait.
+	Timeout time.Duration
+
+	// Delay is waited before the first poll.
+	Delay time.Duration
+
+	// MinInterval is the delay after the first poll. It doubles after every
+	// poll, up to MaxInterval. Set both to the same value for a fixed
+	// interval.
+	MinInterval time.Duration
+
+	// MaxInterval caps the delay between polls.
+	MaxInterval time.Duration
+
+	// NoJitter disables the randomization of the delay between polls. By
+	// default, each delay is a random value between half and all of the
+	// computed delay.
+	NoJitter bool
+}
+
+// ErrUnexpectedStatus is returned by WaitForStatus when a resource enters a
+// failed status, or a status that is neither targeted nor pending.
+type ErrUnexpectedStatus struct {
+	BaseError
+	Status   string
+	Expected []string
+	Resource interface{}
+}
+
+func (e ErrUnexpectedStatus) Error() string {
+	e.DefaultErrString = fmt.Sprintf("Unexpected status %q while waiting for %v", e.Status, e.Expected)
+	return e.choseErrString()
+}
+
+// Wait polls f until it reports done, returns an error, the Waiter's Timeout
+// elapses or ctx is done. It returns the last state f observed. When the
+// Timeout elapses, the error is an ErrTimeOut; when ctx is done, it is the
+// context's error.
+func (w *Waiter) Wait(ctx context.Context, f WaitFunc) (interface{}, error) {
+	waitCtx := ctx
+	if w.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, w.Timeout)
+		defer cancel()
+	}
+
+	min, max := w.MinInterval, w.MaxInterval
+	if min <= 0 {
+		min = DefaultWaitMinInterval
+	}
+	if max <= 0 {
+		max = DefaultWaitMaxInterval
+	}
+	if max < min {
+		max = min
+	}
+
+	var last interface{}
+	delay, interval := w.Delay, min
+	for {
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-waitCtx.Done():
+				timer.Stop()
+				return last, w.doneErr(ctx, last)
+			}
+		} else if waitCtx.Err() != nil {
+			return last, w.doneErr(ctx, last)
+		}
+
+		done, state, err := f(waitCtx)
+		if state != nil {
+			last = state
+		}
+		if err == nil && done {
+			return last, nil
+		}
+		if waitCtx.Err() != nil {
+			return last, w.doneErr(ctx, last)
+		}
+		if err != nil {
+			return last, err
+		}
+
+		delay = interval
+		if !w.NoJitter && delay > 1 {
+			delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+		}
+		if interval *= 2; interval > max {
+			interval = max
+		}
+	}
+}
+
+// doneErr returns the error reported when the wait context is done: the
+// parent context's error if it was cancelled, ErrTimeOut otherwise.
+func (w *Waiter) doneErr(parent context.Context, last interface{}) error {
+	if err := parent.Err(); err != nil {
+		return err
+	}
+	return ErrTimeOut{Timeout: w.Timeout, LastState: last}
+}
+
+// WaitForStatus polls getter until the resource reaches one of the target
+// statuses and returns the resource. A status listed in failed, or a status
+// missing from both target and a non-empty pending list, ends the wait with
+// ErrUnexpectedStatus. An empty pending list accepts any other status as
+// pending.
+func (w *Waiter) WaitForStatus(ctx context.Context, getter StatusGetter, target, pending, failed []string) (interface{}, error) {
+	var resource interface{}
+	_, err := w.Wait(ctx, func(ctx context.Context) (bool, interface{}, error) {
+		r, status, err := getter()
+		if err != nil {
+			return false, nil, err
+		}
+		resource = r
+
+		switch {
+		case containsString(target, status):
+			return true, status, nil
+		case containsString(failed, status):
+			return false, status, ErrUnexpectedStatus{Status: status, Expected: target, Resource: r}
+		case len(pending) > 0 && !containsString(pending, status):
+			return false, status, ErrUnexpectedStatus{Status: status, Expected: target, Resource: r}
+		}
+		return false, status, nil
+	})
+	return resource, err
+}
+
+// WaitForStatus waits up to timeout for a resource to reach one of the target
+// statuses, polling getter with the default Waiter backoff. See
+// Waiter.WaitForStatus for the meaning of pending and failed.
+func WaitForStatus(getter StatusGetter, target, pending, failed []string, timeout time.Duration) (interface{}, error) {
+	w := Waiter{Timeout: timeout}
+	return w.WaitForStatus(context.Background(), getter, target, pending, failed)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}